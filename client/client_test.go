@@ -0,0 +1,122 @@
+package client
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+
+	"github.com/fiorix/protoc-gen-cobra/generator"
+)
+
+// testBankFile is a proto file of a service with a unary method.
+func testBankFile() *pb.FileDescriptorProto {
+	field := func(name string, typ pb.FieldDescriptorProto_Type) *pb.FieldDescriptorProto {
+		return &pb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(1),
+			Label:    pb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	return &pb.FileDescriptorProto{
+		Name:    proto.String("bank.proto"),
+		Package: proto.String("bank"),
+		Syntax:  proto.String("proto3"),
+		Options: &pb.FileOptions{GoPackage: proto.String("example.com/bank;bank")},
+		MessageType: []*pb.DescriptorProto{
+			{Name: proto.String("DepositRequest"), Field: []*pb.FieldDescriptorProto{field("account", pb.FieldDescriptorProto_TYPE_STRING)}},
+			{Name: proto.String("DepositReply"), Field: []*pb.FieldDescriptorProto{field("balance", pb.FieldDescriptorProto_TYPE_DOUBLE)}},
+		},
+		Service: []*pb.ServiceDescriptorProto{{
+			Name: proto.String("Bank"),
+			Method: []*pb.MethodDescriptorProto{{
+				Name:       proto.String("Deposit"),
+				InputType:  proto.String(".bank.DepositRequest"),
+				OutputType: proto.String(".bank.DepositReply"),
+			}},
+		}},
+	}
+}
+
+// generate runs the generator with the client plugin and the parameter
+// over file, and returns the parsed code generated for it.
+func generate(t *testing.T, parameter string, file *pb.FileDescriptorProto) (*token.FileSet, *ast.File) {
+	t.Helper()
+	g := generator.New()
+	g.Request = &plugin.CodeGeneratorRequest{
+		FileToGenerate: []string{file.GetName()},
+		Parameter:      proto.String(parameter),
+		ProtoFile:      []*pb.FileDescriptorProto{file},
+	}
+	g.CommandLineParameters(g.Request.GetParameter())
+	g.WrapTypes()
+	g.SetPackageNames()
+	g.BuildTypeNameMap()
+	g.GenerateAllFiles()
+	if g.Response.Error != nil {
+		t.Fatal(g.Response.GetError())
+	}
+	if len(g.Response.File) != 1 {
+		t.Fatalf("generated %d files, want 1", len(g.Response.File))
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, g.Response.File[0].GetName(), g.Response.File[0].GetContent(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fset, f
+}
+
+func TestDialReturnsConnectionError(t *testing.T) {
+	fset, f := generate(t, "plugins=client", testBankFile())
+	var dial *ast.FuncDecl
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == "_DialBank" {
+			dial = fn
+		}
+	}
+	if dial == nil {
+		t.Fatal("_DialBank not generated")
+	}
+	// The dial options the function starts with.
+	var opts *ast.CompositeLit
+	ast.Inspect(dial.Body, func(n ast.Node) bool {
+		if as, ok := n.(*ast.AssignStmt); ok && opts == nil && len(as.Lhs) == 1 {
+			if id, ok := as.Lhs[0].(*ast.Ident); ok && id.Name == "opts" {
+				opts, _ = as.Rhs[0].(*ast.CompositeLit)
+			}
+		}
+		return opts == nil
+	})
+	if opts == nil {
+		t.Fatal("_DialBank has no dial options")
+	}
+	var got []string
+	for _, e := range opts.Elts {
+		var b strings.Builder
+		printer.Fprint(&b, fset, e)
+		got = append(got, b.String())
+	}
+	has := func(opt string) bool {
+		for _, s := range got {
+			if s == opt {
+				return true
+			}
+		}
+		return false
+	}
+	if !has("grpc.WithBlock()") {
+		t.Fatalf("dial options %v are not blocking", got)
+	}
+	if !has("grpc.WithReturnConnectionError()") {
+		t.Errorf("blocking dial options %v do not return the connection error", got)
+	}
+}
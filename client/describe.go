@@ -0,0 +1,105 @@
+// Copyright 2016 The protoc-gen-cobra authors. All rights reserved.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	pb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	"github.com/fiorix/protoc-gen-cobra/generator"
+)
+
+// describeDepth is the number of levels of nested messages expanded by
+// describeMessage.
+const describeDepth = 4
+
+// describeMessage returns the field tree of the message named typeName:
+// a line per field with its name and type, followed by the fields of
+// message types indented under it.
+func (c *client) describeMessage(typeName string) string {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, strings.TrimPrefix(typeName, "."))
+	c.describeFields(&b, typeName, 1, map[string]bool{typeName: true})
+	return b.String()
+}
+
+// describeFields writes the fields of the message named typeName to b, at
+// the given depth. Messages in parents, which would expand forever, are
+// not expanded.
+func (c *client) describeFields(b *bytes.Buffer, typeName string, depth int, parents map[string]bool) {
+	desc := c.gen.ObjectNamed(typeName).(*generator.Descriptor)
+	indent := strings.Repeat("  ", depth)
+	for _, field := range desc.Field {
+		fmt.Fprintf(b, "%s%s %s", indent, field.GetName(), c.describeType(field))
+		switch {
+		case field.GetProto3Optional():
+			fmt.Fprint(b, " (optional)")
+		case field.OneofIndex != nil:
+			fmt.Fprintf(b, " (oneof %s)", desc.OneofDecl[field.GetOneofIndex()].GetName())
+		}
+		if field.GetOptions().GetDeprecated() {
+			fmt.Fprint(b, " (deprecated)")
+		}
+		value := field
+		if c.isMapField(field) {
+			value = c.gen.ObjectNamed(field.GetTypeName()).(*generator.Descriptor).Field[1]
+		}
+		if value.GetType() != pb.FieldDescriptorProto_TYPE_MESSAGE && value.GetType() != pb.FieldDescriptorProto_TYPE_GROUP {
+			fmt.Fprintln(b)
+			continue
+		}
+		switch {
+		case parents[value.GetTypeName()]:
+			fmt.Fprintln(b, " (recursive)")
+		case depth >= describeDepth:
+			fmt.Fprintln(b, " ...")
+		default:
+			fmt.Fprintln(b)
+			parents[value.GetTypeName()] = true
+			c.describeFields(b, value.GetTypeName(), depth+1, parents)
+			delete(parents, value.GetTypeName())
+		}
+	}
+}
+
+// describeType returns the type of field, e.g. "repeated string" or
+// "map<string, pkg.Value>".
+func (c *client) describeType(field *pb.FieldDescriptorProto) string {
+	if c.isMapField(field) {
+		entry := c.gen.ObjectNamed(field.GetTypeName()).(*generator.Descriptor)
+		return fmt.Sprintf("map<%s, %s>", c.describeValueType(entry.Field[0]), c.describeValueType(entry.Field[1]))
+	}
+	if field.GetLabel() == pb.FieldDescriptorProto_LABEL_REPEATED {
+		return "repeated " + c.describeValueType(field)
+	}
+	return c.describeValueType(field)
+}
+
+// describeValueType returns the type of a single value of field. Enums
+// list their values.
+func (c *client) describeValueType(field *pb.FieldDescriptorProto) string {
+	switch field.GetType() {
+	case pb.FieldDescriptorProto_TYPE_MESSAGE, pb.FieldDescriptorProto_TYPE_GROUP:
+		return strings.TrimPrefix(field.GetTypeName(), ".")
+	case pb.FieldDescriptorProto_TYPE_ENUM:
+		enum := c.gen.ObjectNamed(field.GetTypeName()).(*generator.EnumDescriptor)
+		names := make([]string, len(enum.Value))
+		for i, v := range enum.Value {
+			names[i] = fmt.Sprintf("%s=%d", v.GetName(), v.GetNumber())
+		}
+		return fmt.Sprintf("%s enum (%s)", strings.TrimPrefix(field.GetTypeName(), "."), strings.Join(names, ", "))
+	}
+	return fieldType(field)
+}
+
+// isMapField reports whether field is a map, i.e. a repeated map entry.
+func (c *client) isMapField(field *pb.FieldDescriptorProto) bool {
+	if field.GetType() != pb.FieldDescriptorProto_TYPE_MESSAGE || field.GetLabel() != pb.FieldDescriptorProto_LABEL_REPEATED {
+		return false
+	}
+	desc := c.gen.ObjectNamed(field.GetTypeName()).(*generator.Descriptor)
+	return desc.GetOptions().GetMapEntry()
+}
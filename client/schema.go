@@ -0,0 +1,148 @@
+// Copyright 2016 The protoc-gen-cobra authors. All rights reserved.
+
+package client
+
+import (
+	"encoding/json"
+	"strings"
+
+	pb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+
+	"github.com/fiorix/protoc-gen-cobra/generator"
+)
+
+// requestSchema returns the JSON schema of the message named typeName in
+// the JSON mapping of proto3, which protojson decodes json requests from.
+// 64-bit integers may be strings, enums names, and well-known types have
+// their json forms, e.g. RFC 3339 strings for timestamps.
+func (c *client) requestSchema(typeName string) string {
+	defs := map[string]interface{}{}
+	c.messageSchema(typeName, defs)
+	name := strings.TrimPrefix(typeName, ".")
+	schema := map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"$ref":        "#/definitions/" + name,
+		"definitions": defs,
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		c.gen.Error(err, "marshal schema for", name)
+	}
+	return string(b)
+}
+
+// messageSchema adds the schema of the message named typeName, and of the
+// messages it refers to, to defs.
+func (c *client) messageSchema(typeName string, defs map[string]interface{}) {
+	name := strings.TrimPrefix(typeName, ".")
+	if _, ok := defs[name]; ok {
+		return
+	}
+	desc := c.gen.ObjectNamed(typeName).(*generator.Descriptor)
+	props := map[string]interface{}{}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"title":      desc.GetName(),
+		"properties": props,
+	}
+	defs[name] = schema
+	for _, field := range desc.Field {
+		props[field.GetName()] = c.fieldSchema(field, defs)
+	}
+}
+
+// fieldSchema returns the schema of field, adding the messages it refers
+// to to defs.
+func (c *client) fieldSchema(field *pb.FieldDescriptorProto, defs map[string]interface{}) map[string]interface{} {
+	if field.GetType() == pb.FieldDescriptorProto_TYPE_MESSAGE {
+		desc := c.gen.ObjectNamed(field.GetTypeName()).(*generator.Descriptor)
+		if desc.GetOptions().GetMapEntry() {
+			return map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": c.fieldSchema(desc.Field[1], defs),
+			}
+		}
+	}
+	schema := c.valueSchema(field, defs)
+	if field.GetLabel() == pb.FieldDescriptorProto_LABEL_REPEATED {
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schema,
+		}
+	}
+	return schema
+}
+
+// valueSchema returns the schema of a single value of field.
+func (c *client) valueSchema(field *pb.FieldDescriptorProto, defs map[string]interface{}) map[string]interface{} {
+	switch field.GetType() {
+	case pb.FieldDescriptorProto_TYPE_ENUM:
+		if field.GetTypeName() == ".google.protobuf.NullValue" {
+			return map[string]interface{}{"type": "null"}
+		}
+		enum := c.gen.ObjectNamed(field.GetTypeName()).(*generator.EnumDescriptor)
+		var values []interface{}
+		for _, v := range enum.Value {
+			values = append(values, v.GetName())
+		}
+		for _, v := range enum.Value {
+			values = append(values, v.GetNumber())
+		}
+		return map[string]interface{}{
+			"type": []string{"string", "integer"},
+			"enum": values,
+		}
+	case pb.FieldDescriptorProto_TYPE_MESSAGE,
+		pb.FieldDescriptorProto_TYPE_GROUP:
+		if schema, ok := wellKnownSchemas[field.GetTypeName()]; ok {
+			return schema
+		}
+		c.messageSchema(field.GetTypeName(), defs)
+		return map[string]interface{}{"$ref": "#/definitions/" + strings.TrimPrefix(field.GetTypeName(), ".")}
+	}
+	return scalarSchema(field.GetType())
+}
+
+// scalarSchema returns the schema of a value of the scalar type t.
+// Integers may be strings, as 64-bit integers are in the json form, and
+// floating-point numbers may be strings such as "NaN" and "Infinity".
+func scalarSchema(t pb.FieldDescriptorProto_Type) map[string]interface{} {
+	switch t {
+	case pb.FieldDescriptorProto_TYPE_DOUBLE,
+		pb.FieldDescriptorProto_TYPE_FLOAT:
+		return map[string]interface{}{"type": []string{"number", "string"}}
+	case pb.FieldDescriptorProto_TYPE_BOOL:
+		return map[string]interface{}{"type": "boolean"}
+	case pb.FieldDescriptorProto_TYPE_STRING:
+		return map[string]interface{}{"type": "string"}
+	case pb.FieldDescriptorProto_TYPE_BYTES:
+		return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+	}
+	return map[string]interface{}{"type": []string{"integer", "string"}}
+}
+
+// wellKnownSchemas are the schemas of the json forms of the well-known
+// types, by type name. Wrappers are their wrapped values.
+var wellKnownSchemas = map[string]map[string]interface{}{
+	".google.protobuf.Timestamp": {"type": "string", "format": "date-time"},
+	".google.protobuf.Duration":  {"type": "string", "pattern": `^-?[0-9]+(\.[0-9]{0,9})?s$`},
+	".google.protobuf.FieldMask": {"type": "string"},
+	".google.protobuf.Struct":    {"type": "object"},
+	".google.protobuf.Value":     {},
+	".google.protobuf.ListValue": {"type": "array"},
+	".google.protobuf.Empty":     {"type": "object"},
+	".google.protobuf.Any": {
+		"type":       "object",
+		"properties": map[string]interface{}{"@type": map[string]interface{}{"type": "string"}},
+		"required":   []string{"@type"},
+	},
+	".google.protobuf.DoubleValue": scalarSchema(pb.FieldDescriptorProto_TYPE_DOUBLE),
+	".google.protobuf.FloatValue":  scalarSchema(pb.FieldDescriptorProto_TYPE_FLOAT),
+	".google.protobuf.Int64Value":  scalarSchema(pb.FieldDescriptorProto_TYPE_INT64),
+	".google.protobuf.UInt64Value": scalarSchema(pb.FieldDescriptorProto_TYPE_UINT64),
+	".google.protobuf.Int32Value":  scalarSchema(pb.FieldDescriptorProto_TYPE_INT32),
+	".google.protobuf.UInt32Value": scalarSchema(pb.FieldDescriptorProto_TYPE_UINT32),
+	".google.protobuf.BoolValue":   scalarSchema(pb.FieldDescriptorProto_TYPE_BOOL),
+	".google.protobuf.StringValue": scalarSchema(pb.FieldDescriptorProto_TYPE_STRING),
+	".google.protobuf.BytesValue":  scalarSchema(pb.FieldDescriptorProto_TYPE_BYTES),
+}
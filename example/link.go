@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/fiorix/protoc-gen-cobra/example/cmd"
 	"github.com/fiorix/protoc-gen-cobra/example/pb"
+	"github.com/fiorix/protoc-gen-cobra/example/things"
 )
 
 func init() {
@@ -10,4 +11,5 @@ func init() {
 	cmd.RootCmd.AddCommand(pb.BankClientCommand)
 	cmd.RootCmd.AddCommand(pb.CacheClientCommand)
 	cmd.RootCmd.AddCommand(pb.TimerClientCommand)
+	cmd.RootCmd.AddCommand(things.ThingsClientCommand)
 }
@@ -0,0 +1,326 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: things.proto
+
+package things
+
+import (
+	context "context"
+	_ "github.com/fiorix/protoc-gen-cobra/options"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetThingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThingRequest) Reset() {
+	*x = GetThingRequest{}
+	mi := &file_things_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThingRequest) ProtoMessage() {}
+
+func (x *GetThingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_things_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThingRequest.ProtoReflect.Descriptor instead.
+func (*GetThingRequest) Descriptor() ([]byte, []int) {
+	return file_things_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetThingRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Thing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Updated       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Thing) Reset() {
+	*x = Thing{}
+	mi := &file_things_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Thing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Thing) ProtoMessage() {}
+
+func (x *Thing) ProtoReflect() protoreflect.Message {
+	mi := &file_things_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Thing.ProtoReflect.Descriptor instead.
+func (*Thing) Descriptor() ([]byte, []int) {
+	return file_things_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Thing) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Thing) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Thing) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+var File_things_proto protoreflect.FileDescriptor
+
+const file_things_proto_rawDesc = "" +
+	"\n" +
+	"\fthings.proto\x12\x06things\x1a6github.com/fiorix/protoc-gen-cobra/options/cobra.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\">\n" +
+	"\x0fGetThingRequest\x12+\n" +
+	"\x04name\x18\x01 \x01(\tB\x17\xe2\xf9\x18\x13\x1a\x11name of the thingR\x04name\"s\n" +
+	"\x05Thing\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x124\n" +
+	"\aupdated\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aupdated2\x8d\x01\n" +
+	"\x06Things\x12@\n" +
+	"\x03Get\x12\x17.things.GetThingRequest\x1a\r.things.Thing\"\x11\xe2\xf9\x18\r\n" +
+	"\x01g\x1a\x025s\"\x04name\x127\n" +
+	"\x05Reset\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x1a\b\xe2\xf9\x18\x04\n" +
+	"\x02thB3Z1github.com/fiorix/protoc-gen-cobra/example/thingsb\x06proto3"
+
+var (
+	file_things_proto_rawDescOnce sync.Once
+	file_things_proto_rawDescData []byte
+)
+
+func file_things_proto_rawDescGZIP() []byte {
+	file_things_proto_rawDescOnce.Do(func() {
+		file_things_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_things_proto_rawDesc), len(file_things_proto_rawDesc)))
+	})
+	return file_things_proto_rawDescData
+}
+
+var file_things_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_things_proto_goTypes = []any{
+	(*GetThingRequest)(nil),       // 0: things.GetThingRequest
+	(*Thing)(nil),                 // 1: things.Thing
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 3: google.protobuf.Empty
+}
+var file_things_proto_depIdxs = []int32{
+	2, // 0: things.Thing.updated:type_name -> google.protobuf.Timestamp
+	0, // 1: things.Things.Get:input_type -> things.GetThingRequest
+	3, // 2: things.Things.Reset:input_type -> google.protobuf.Empty
+	1, // 3: things.Things.Get:output_type -> things.Thing
+	3, // 4: things.Things.Reset:output_type -> google.protobuf.Empty
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_things_proto_init() }
+func file_things_proto_init() {
+	if File_things_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_things_proto_rawDesc), len(file_things_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_things_proto_goTypes,
+		DependencyIndexes: file_things_proto_depIdxs,
+		MessageInfos:      file_things_proto_msgTypes,
+	}.Build()
+	File_things_proto = out.File
+	file_things_proto_goTypes = nil
+	file_things_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ThingsClient is the client API for Things service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ThingsClient interface {
+	// Get returns the thing of a name.
+	Get(ctx context.Context, in *GetThingRequest, opts ...grpc.CallOption) (*Thing, error)
+	// Reset removes all things.
+	Reset(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type thingsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewThingsClient(cc grpc.ClientConnInterface) ThingsClient {
+	return &thingsClient{cc}
+}
+
+func (c *thingsClient) Get(ctx context.Context, in *GetThingRequest, opts ...grpc.CallOption) (*Thing, error) {
+	out := new(Thing)
+	err := c.cc.Invoke(ctx, "/things.Things/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thingsClient) Reset(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/things.Things/Reset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ThingsServer is the server API for Things service.
+type ThingsServer interface {
+	// Get returns the thing of a name.
+	Get(context.Context, *GetThingRequest) (*Thing, error)
+	// Reset removes all things.
+	Reset(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+}
+
+// UnimplementedThingsServer can be embedded to have forward compatible implementations.
+type UnimplementedThingsServer struct {
+}
+
+func (*UnimplementedThingsServer) Get(context.Context, *GetThingRequest) (*Thing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedThingsServer) Reset(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
+
+func RegisterThingsServer(s *grpc.Server, srv ThingsServer) {
+	s.RegisterService(&_Things_serviceDesc, srv)
+}
+
+func _Things_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/things.Things/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).Get(ctx, req.(*GetThingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Things_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThingsServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/things.Things/Reset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThingsServer).Reset(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Things_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "things.Things",
+	HandlerType: (*ThingsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _Things_Get_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _Things_Reset_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "things.proto",
+}
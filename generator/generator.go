@@ -117,6 +117,36 @@ func (d *Descriptor) TypeName() []string {
 	return s
 }
 
+// EnumDescriptor describes an enum. If it's at top level, its parent will be nil.
+// Otherwise it will be the descriptor of the message in which it is defined.
+type EnumDescriptor struct {
+	common
+	*descriptor.EnumDescriptorProto
+	parent   *Descriptor // The containing message, if any.
+	typename []string    // Cached typename vector.
+	index    int         // The index into the container, whether the file or a message.
+	path     string      // The SourceCodeInfo path as comma-separated integers.
+}
+
+// TypeName returns the elements of the dotted type name.
+// The package name is not part of this name.
+func (e *EnumDescriptor) TypeName() (s []string) {
+	if e.typename != nil {
+		return e.typename
+	}
+	name := e.GetName()
+	if e.parent == nil {
+		s = make([]string, 1)
+	} else {
+		pname := e.parent.TypeName()
+		s = make([]string, len(pname)+1)
+		copy(s, pname)
+	}
+	s[len(s)-1] = name
+	e.typename = s
+	return s
+}
+
 // ExtensionDescriptor describes an extension. If it's at top level, its parent will be nil.
 // Otherwise it will be the descriptor of the message in which it is defined.
 type ExtensionDescriptor struct {
@@ -166,6 +196,7 @@ func (id *ImportedDescriptor) TypeName() []string { return id.o.TypeName() }
 type FileDescriptor struct {
 	*descriptor.FileDescriptorProto
 	desc []*Descriptor          // All the messages defined in this file.
+	enum []*EnumDescriptor      // All the enums defined in this file.
 	ext  []*ExtensionDescriptor // All the top-level extensions defined in this file.
 	imp  []*ImportedDescriptor  // All types defined in files publicly imported by this file.
 
@@ -215,6 +246,16 @@ func (d *FileDescriptor) goPackageOption() (impPath, pkg string, ok bool) {
 	return
 }
 
+// GoPackage returns the import path and name of the Go package of the
+// file, set by its go_package option, or an empty import path if the
+// option doesn't imply one.
+func (d *FileDescriptor) GoPackage() (importPath, name string) {
+	importPath, _, _ = d.goPackageOption()
+	name, _ = d.goPackageName()
+	name = strings.Map(badToUnderscore, name)
+	return importPath, name
+}
+
 // goPackageName returns the Go package name to use in the
 // generated Go file.  The result explicit reports whether the name
 // came from an option go_package statement.  If explicit is false,
@@ -254,6 +295,12 @@ func (d *FileDescriptor) goFileName() string {
 	return name
 }
 
+// goServiceFileName returns the output name of the generated file of the
+// named service, when generating one file per service.
+func (d *FileDescriptor) goServiceFileName(service string) string {
+	return path.Join(path.Dir(d.goFileName()), strings.ToLower(service)+".cobra.pb.go")
+}
+
 func (d *FileDescriptor) addExport(obj Object, sym symbol) {
 	d.exported[obj] = append(d.exported[obj], sym)
 }
@@ -298,6 +345,10 @@ type Generator struct {
 
 	Pkg map[string]string // The names under which we import support packages
 
+	// ServiceIndex is the index of the service to generate code for, when
+	// generating one file per service, or -1 to generate all services.
+	ServiceIndex int
+
 	packageName      string                     // What we're calling ourselves.
 	allFiles         []*FileDescriptor          // All files in the tree
 	allFilesByName   map[string]*FileDescriptor // All files by filename.
@@ -308,6 +359,7 @@ type Generator struct {
 	init             []string                   // Lines to emit in the init function.
 	indent           string
 	writeOutput      bool
+	perServiceFiles  bool // Whether to generate one file per service.
 }
 
 // New creates a new generator and allocates the request and response protobufs.
@@ -316,6 +368,7 @@ func New() *Generator {
 	g.Buffer = new(bytes.Buffer)
 	g.Request = new(plugin.CodeGeneratorRequest)
 	g.Response = new(plugin.CodeGeneratorResponse)
+	g.ServiceIndex = -1
 	return g
 }
 
@@ -333,6 +386,12 @@ func (g *Generator) Fail(msgs ...string) {
 	os.Exit(1)
 }
 
+// Warn reports a problem that does not stop code generation.
+func (g *Generator) Warn(msgs ...string) {
+	s := strings.Join(msgs, " ")
+	log.Print("protoc-gen-cobra: warning: ", s)
+}
+
 // CommandLineParameters breaks the comma-separated list of key=value pairs
 // in the parameter (a member of the request protobuf) into a key/value map.
 // It then sets file name mappings defined by those entries.
@@ -357,6 +416,12 @@ func (g *Generator) CommandLineParameters(parameter string) {
 			g.PackageImportPath = v
 		case "plugins":
 			pluginList = v
+		case "per_service_files":
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				g.Error(err, "parse", k)
+			}
+			g.perServiceFiles = b
 		default:
 			if len(k) > 0 && k[0] == 'M' {
 				g.ImportMap[k[1:]] = v
@@ -549,10 +614,12 @@ func (g *Generator) WrapTypes() {
 		// We must wrap the descriptors before we wrap the enums
 		descs := wrapDescriptors(f)
 		g.buildNestedDescriptors(descs)
+		enums := wrapEnumDescriptors(f, descs)
 		exts := wrapExtensions(f)
 		fd := &FileDescriptor{
 			FileDescriptorProto: f,
 			desc:                descs,
+			enum:                enums,
 			ext:                 exts,
 			exported:            make(map[Object][]symbol),
 			proto3:              fileIsProto3(f),
@@ -576,6 +643,40 @@ func (g *Generator) WrapTypes() {
 	}
 }
 
+// BuildTypeNameMap builds the map from fully qualified type names to objects.
+// The key names for the map come from the input data, which puts a period at the beginning.
+// It should be called after SetPackageNames and before GenerateAllFiles.
+func (g *Generator) BuildTypeNameMap() {
+	g.typeNameToObject = make(map[string]Object)
+	for _, f := range g.allFiles {
+		// The names in this loop are defined by the proto world, not us, so the
+		// package name may be empty.  If so, the dotted package name of X will
+		// be ".X"; otherwise it will be ".pkg.X".
+		dottedPkg := "." + f.GetPackage()
+		if dottedPkg != "." {
+			dottedPkg += "."
+		}
+		for _, enum := range f.enum {
+			name := dottedPkg + dottedSlice(enum.TypeName())
+			g.typeNameToObject[name] = enum
+		}
+		for _, desc := range f.desc {
+			name := dottedPkg + dottedSlice(desc.TypeName())
+			g.typeNameToObject[name] = desc
+		}
+	}
+}
+
+// ObjectNamed, given a fully-qualified input type name as it appears in the input data,
+// returns the descriptor for the message or enum with that name.
+func (g *Generator) ObjectNamed(typeName string) Object {
+	o, ok := g.typeNameToObject[typeName]
+	if !ok {
+		g.Fail("can't find object with type", typeName)
+	}
+	return o
+}
+
 // Scan the descriptors in this file.  For each one, build the slice of nested descriptors
 func (g *Generator) buildNestedDescriptors(descs []*Descriptor) {
 	for _, desc := range descs {
@@ -648,6 +749,38 @@ func wrapThisDescriptor(sl []*Descriptor, desc *descriptor.DescriptorProto, pare
 	return sl
 }
 
+// Return a slice of all the EnumDescriptors defined within this file
+func wrapEnumDescriptors(file *descriptor.FileDescriptorProto, descs []*Descriptor) []*EnumDescriptor {
+	sl := make([]*EnumDescriptor, 0, len(file.EnumType)+10)
+	// Top-level enums.
+	for i, enum := range file.EnumType {
+		sl = append(sl, newEnumDescriptor(enum, nil, file, i))
+	}
+	// Enums within messages. Enums within embedded messages appear in the outer-most message.
+	for _, nested := range descs {
+		for i, enum := range nested.EnumType {
+			sl = append(sl, newEnumDescriptor(enum, nested, file, i))
+		}
+	}
+	return sl
+}
+
+// Construct the EnumDescriptor
+func newEnumDescriptor(desc *descriptor.EnumDescriptorProto, parent *Descriptor, file *descriptor.FileDescriptorProto, index int) *EnumDescriptor {
+	ed := &EnumDescriptor{
+		common:              common{file},
+		EnumDescriptorProto: desc,
+		parent:              parent,
+		index:               index,
+	}
+	if parent == nil {
+		ed.path = fmt.Sprintf("%d,%d", enumPath, index)
+	} else {
+		ed.path = fmt.Sprintf("%s,%d,%d", parent.path, messageEnumPath, index)
+	}
+	return ed
+}
+
 // Return a slice of all the top-level ExtensionDescriptors defined within this file.
 func wrapExtensions(file *descriptor.FileDescriptorProto) []*ExtensionDescriptor {
 	var sl []*ExtensionDescriptor
@@ -752,6 +885,10 @@ func (g *Generator) GenerateAllFiles() {
 		genFileMap[file] = true
 	}
 	for _, file := range g.allFiles {
+		if g.perServiceFiles && genFileMap[file] && len(file.Service) > 0 {
+			g.generatePerService(file)
+			continue
+		}
 		g.Reset()
 		g.writeOutput = genFileMap[file]
 		g.generate(file)
@@ -765,6 +902,21 @@ func (g *Generator) GenerateAllFiles() {
 	}
 }
 
+// generatePerService generates one output file per service of file.
+func (g *Generator) generatePerService(file *FileDescriptor) {
+	for i, service := range file.Service {
+		g.Reset()
+		g.writeOutput = true
+		g.ServiceIndex = i
+		g.generate(file)
+		g.Response.File = append(g.Response.File, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(file.goServiceFileName(service.GetName())),
+			Content: proto.String(g.String()),
+		})
+	}
+	g.ServiceIndex = -1
+}
+
 // Run all the plugins associated with the file.
 func (g *Generator) runPlugins(file *FileDescriptor) {
 	for _, p := range plugins {
@@ -799,16 +951,20 @@ func (g *Generator) generate(file *FileDescriptor) {
 		g.P()
 	}
 
-	for _, td := range g.file.imp {
-		g.generateImported(td)
-	}
-	for _, desc := range g.file.desc {
-		// Don't generate virtual messages for maps.
-		if desc.GetOptions().GetMapEntry() {
-			continue
+	// Per service files share the package level declarations of the
+	// first one.
+	if g.ServiceIndex <= 0 {
+		for _, td := range g.file.imp {
+			g.generateImported(td)
+		}
+		for _, desc := range g.file.desc {
+			// Don't generate virtual messages for maps.
+			if desc.GetOptions().GetMapEntry() {
+				continue
+			}
 		}
+		g.generateInitFunction()
 	}
-	g.generateInitFunction()
 
 	// Run the plugins before the imports so we know which imports are necessary.
 	g.runPlugins(file)
@@ -916,6 +1072,32 @@ func (g *Generator) PrintComments(path string) bool {
 	return false
 }
 
+// Comments returns any comments from the source .proto file and empty string if comments not found.
+// The path is a comma-separated list of integers.
+// See descriptor.proto for its format.
+func (g *Generator) Comments(path string) string {
+	loc, ok := g.file.comments[path]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSuffix(loc.GetLeadingComments(), "\n")
+}
+
+// FieldComments returns any comments of the ith field of the message d from
+// its source .proto file, which need not be the file being generated, and
+// empty string if comments not found.
+func (g *Generator) FieldComments(d *Descriptor, i int) string {
+	fd := g.fileByName(d.file.GetName())
+	if fd == nil {
+		return ""
+	}
+	loc, ok := fd.comments[fmt.Sprintf("%s,%d,%d", d.path, messageFieldPath, i)]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSuffix(loc.GetLeadingComments(), "\n")
+}
+
 func (g *Generator) fileByName(filename string) *FileDescriptor {
 	return g.allFilesByName[filename]
 }
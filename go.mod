@@ -1,4 +1,11 @@
 // Deprecated: use github.com/NathanBaulch/protoc-gen-cobra instead
 module github.com/fiorix/protoc-gen-cobra
 
-go 1.11
+// go-toml/v2 requires go 1.21.0, and the code protoc-gen-go generates,
+// such as options/cobra.pb.go, requires go 1.20.
+go 1.21.0
+
+require (
+	github.com/pelletier/go-toml/v2 v2.2.4
+	gopkg.in/yaml.v3 v3.0.1
+)
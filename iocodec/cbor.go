@@ -0,0 +1,320 @@
+package iocodec
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// cborEncoder writes protobuf messages as CBOR maps of their JSON mapping
+// of proto3, with the options opts, as msgpackEncoder does, so 64-bit
+// integers are text strings, and bytes base64 text strings, as in json.
+// Floating-point numbers are written in double precision.
+type cborEncoder struct {
+	w    io.Writer
+	opts *protojson.MarshalOptions
+}
+
+func (ce *cborEncoder) Encode(v interface{}) error {
+	t, err := jsonTree(v, *ce.opts)
+	if err != nil {
+		return err
+	}
+	_, err = ce.w.Write(appendCBOR(nil, t))
+	return err
+}
+
+// The major types of CBOR data items.
+const (
+	cborUint = iota << 5
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// appendCBOR appends the CBOR encoding of the json tree value v to b.
+func appendCBOR(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xf6)
+	case bool:
+		if v {
+			return append(b, 0xf5)
+		}
+		return append(b, 0xf4)
+	case json.Number:
+		switch n := jsonNumber(v).(type) {
+		case int64:
+			if n >= 0 {
+				return appendCBORHead(b, cborUint, uint64(n))
+			}
+			return appendCBORHead(b, cborNegInt, uint64(-1-n))
+		case uint64:
+			return appendCBORHead(b, cborUint, n)
+		case float64:
+			return appendUint(append(b, 0xfb), math.Float64bits(n), 8)
+		}
+	case string:
+		b = appendCBORHead(b, cborText, uint64(len(v)))
+		return append(b, v...)
+	case []interface{}:
+		b = appendCBORHead(b, cborArray, uint64(len(v)))
+		for _, e := range v {
+			b = appendCBOR(b, e)
+		}
+		return b
+	case jsonObject:
+		b = appendCBORHead(b, cborMap, uint64(len(v)))
+		for _, m := range v {
+			b = appendCBOR(b, m.Key)
+			b = appendCBOR(b, m.Value)
+		}
+		return b
+	}
+	panic(fmt.Sprintf("cbor: unexpected json value %T", v))
+}
+
+// appendCBORHead appends the head of a data item of the major type major
+// and the argument n to b.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return appendUint(append(b, major|25), n, 2)
+	case n <= math.MaxUint32:
+		return appendUint(append(b, major|26), n, 4)
+	}
+	return appendUint(append(b, major|27), n, 8)
+}
+
+// cborDecoder reads a sequence of CBOR data items, one per Decode, and
+// decodes protobuf messages from them with protojson, as msgpackDecoder
+// does. Strings, arrays and maps of indefinite length are supported, and
+// epoch times, of tag 1, are decoded as RFC 3339 timestamps. The contents
+// of other tags are decoded as if they were not tagged, and undefined as
+// null.
+type cborDecoder struct {
+	r      *bufio.Reader
+	strict bool
+}
+
+func (cd *cborDecoder) Decode(v interface{}) error {
+	if _, err := cd.r.Peek(1); err != nil {
+		return err
+	}
+	doc, err := readCBOR(cd.r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == errCBORBreak {
+		err = fmt.Errorf("unexpected break")
+	}
+	if err != nil {
+		return fmt.Errorf("cbor: %v", err)
+	}
+	return decodeTree(doc, v, cd.strict)
+}
+
+// errCBORBreak is returned by readCBOR at the break that ends the items of
+// indefinite length.
+var errCBORBreak = fmt.Errorf("break")
+
+func readCBOR(r *bufio.Reader) (interface{}, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := c&0xe0, c&0x1f
+	if c == 0xff {
+		return nil, errCBORBreak
+	}
+	if info == 31 {
+		return readCBORIndefinite(r, major)
+	}
+	if major == cborSimple {
+		return readCBORSimple(r, info)
+	}
+	n, err := readCBORArg(r, info)
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborUint:
+		return n, nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return -1 - float64(n), nil
+		}
+		return -1 - int64(n), nil
+	case cborBytes:
+		return readN(r, int(n))
+	case cborText:
+		b, err := readN(r, int(n))
+		return string(b), err
+	case cborArray:
+		return readCBORArray(r, int(n))
+	case cborMap:
+		return readCBORMap(r, int(n))
+	}
+	// cborTag
+	e, err := readCBOR(r)
+	if err != nil || n != 1 {
+		return e, err
+	}
+	var sec float64
+	switch e := e.(type) {
+	case uint64:
+		sec = float64(e)
+	case int64:
+		sec = float64(e)
+	case float64:
+		sec = e
+	default:
+		return nil, fmt.Errorf("unexpected epoch time of type %T", e)
+	}
+	whole, frac := math.Modf(sec)
+	return time.Unix(int64(whole), int64(frac*1e9)).UTC(), nil
+}
+
+// readCBORArg reads the argument of a head with the additional
+// information info.
+func readCBORArg(r io.Reader, info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info < 28:
+		return readUint(r, 1<<(info-24))
+	}
+	return 0, fmt.Errorf("unsupported additional information %d", info)
+}
+
+func readCBORSimple(r io.Reader, info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		n, err := readUint(r, 2)
+		return halfFloat(uint16(n)), err
+	case 26:
+		n, err := readUint(r, 4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 27:
+		n, err := readUint(r, 8)
+		return math.Float64frombits(n), err
+	}
+	return nil, fmt.Errorf("unsupported simple value %d", info)
+}
+
+// halfFloat returns the IEEE 754 half-precision number h as a float64.
+func halfFloat(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if mant != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
+
+// readCBORIndefinite reads the items of a string, array or map of the
+// major type major and indefinite length, up to the break that ends them.
+func readCBORIndefinite(r *bufio.Reader, major byte) (interface{}, error) {
+	var items []interface{}
+	for {
+		e, err := readCBOR(r)
+		if err == errCBORBreak {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	switch major {
+	case cborBytes, cborText:
+		var b []byte
+		for _, e := range items {
+			switch e := e.(type) {
+			case []byte:
+				b = append(b, e...)
+			case string:
+				b = append(b, e...)
+			default:
+				return nil, fmt.Errorf("unexpected chunk of type %T", e)
+			}
+		}
+		if major == cborBytes {
+			return b, nil
+		}
+		return string(b), nil
+	case cborArray:
+		if items == nil {
+			items = []interface{}{}
+		}
+		return items, nil
+	case cborMap:
+		if len(items)%2 != 0 {
+			return nil, fmt.Errorf("map without the value of its last key")
+		}
+		m := make(map[string]interface{}, len(items)/2)
+		for i := 0; i < len(items); i += 2 {
+			m[mapKey(items[i])] = items[i+1]
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("unexpected indefinite length of major type %d", major>>5)
+}
+
+func readCBORArray(r *bufio.Reader, n int) (interface{}, error) {
+	a := make([]interface{}, 0, capHint(n))
+	for i := 0; i < n; i++ {
+		e, err := readCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, e)
+	}
+	return a, nil
+}
+
+func readCBORMap(r *bufio.Reader, n int) (interface{}, error) {
+	m := make(map[string]interface{}, capHint(n))
+	for i := 0; i < n; i++ {
+		k, err := readCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+		e, err := readCBOR(r)
+		if err != nil {
+			return nil, err
+		}
+		m[mapKey(k)] = e
+	}
+	return m, nil
+}
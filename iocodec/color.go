@@ -0,0 +1,228 @@
+package iocodec
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+// ColorEncoders contains the encoders per MIME type that highlight the
+// syntax of their output with ANSI escape codes, for terminals.
+var ColorEncoders = EncoderGroup{
+	"json":       EncoderMakerFunc(func(w io.Writer) Encoder { return &colorJSONEncoder{w, false} }),
+	"prettyjson": EncoderMakerFunc(func(w io.Writer) Encoder { return &colorJSONEncoder{w, true} }),
+	"ndjson":     EncoderMakerFunc(func(w io.Writer) Encoder { return &colorJSONEncoder{w, false} }),
+	"yaml":       EncoderMakerFunc(func(w io.Writer) Encoder { return newColorYAMLEncoder(w) }),
+}
+
+// ANSI escape codes of the highlighted JSON and YAML tokens.
+const (
+	colorReset   = "\x1b[0m"
+	colorKey     = "\x1b[34;1m"
+	colorString  = "\x1b[32m"
+	colorNumber  = "\x1b[36m"
+	colorLiteral = "\x1b[35m"
+)
+
+type colorJSONEncoder struct {
+	w      io.Writer
+	pretty bool
+}
+
+func (ce *colorJSONEncoder) Encode(v interface{}) error {
+	var b bytes.Buffer
+	if err := (&jsonEncoder{&b, ce.pretty, &ProtoJSONOptions}).Encode(v); err != nil {
+		return err
+	}
+	_, err := ce.w.Write(colorJSON(b.Bytes()))
+	return err
+}
+
+// colorJSON returns the JSON document b with its keys, strings, numbers,
+// and literals highlighted.
+func colorJSON(b []byte) []byte {
+	var out bytes.Buffer
+	for i := 0; i < len(b); {
+		c := b[i]
+		j := i + 1
+		var color string
+		switch {
+		case c == '"':
+			for j < len(b) && b[j] != '"' {
+				if b[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j < len(b) {
+				j++
+			}
+			color = colorString
+			k := j
+			for k < len(b) && (b[k] == ' ' || b[k] == '\t' || b[k] == '\n' || b[k] == '\r') {
+				k++
+			}
+			if k < len(b) && b[k] == ':' {
+				color = colorKey
+			}
+		case c == '-' || (c >= '0' && c <= '9'):
+			for j < len(b) && bytes.IndexByte([]byte("+-.eE0123456789"), b[j]) >= 0 {
+				j++
+			}
+			color = colorNumber
+		case c >= 'a' && c <= 'z':
+			for j < len(b) && b[j] >= 'a' && b[j] <= 'z' {
+				j++
+			}
+			color = colorLiteral
+		default:
+			out.WriteByte(c)
+			i++
+			continue
+		}
+		out.WriteString(color)
+		out.Write(b[i:j])
+		out.WriteString(colorReset)
+		i = j
+	}
+	return out.Bytes()
+}
+
+// colorYAMLEncoder writes the documents of protoYAMLEncoder with their
+// keys, strings, numbers and literals highlighted, one document at a
+// time, as yaml.v3 writes them.
+type colorYAMLEncoder struct {
+	w io.Writer
+	b bytes.Buffer
+	e *protoYAMLEncoder
+}
+
+func newColorYAMLEncoder(w io.Writer) *colorYAMLEncoder {
+	ce := &colorYAMLEncoder{w: w}
+	ce.e = newProtoYAMLEncoder(&ce.b, &ProtoJSONOptions)
+	return ce
+}
+
+func (ce *colorYAMLEncoder) Encode(v interface{}) error {
+	if err := ce.e.Encode(v); err != nil {
+		return err
+	}
+	_, err := ce.w.Write(colorYAML(ce.b.Bytes()))
+	ce.b.Reset()
+	return err
+}
+
+// colorYAML returns the YAML b, in the block style yaml.v3 writes, with
+// its keys, strings, numbers and literals highlighted. In that style each
+// line has at most a key and a scalar, after the dashes of the sequences
+// it starts entries of, and strings of several lines are literal blocks,
+// whose lines are indented under the line of their key.
+func colorYAML(b []byte) []byte {
+	var out bytes.Buffer
+	block := -1 // the indentation of the line a literal block is under
+	for _, line := range bytes.SplitAfter(b, []byte("\n")) {
+		text := bytes.TrimRight(line, "\n")
+		s := bytes.TrimLeft(text, " ")
+		indent := len(text) - len(s)
+		if block >= 0 && (indent > block || len(s) == 0) {
+			out.Write(text[:indent])
+			writeColor(&out, colorString, s)
+			out.Write(line[len(text):])
+			continue
+		}
+		block = -1
+		out.Write(text[:indent])
+		for bytes.HasPrefix(s, []byte("- ")) {
+			out.WriteString("- ")
+			s = s[2:]
+		}
+		if k := yamlKeyEnd(s); k >= 0 {
+			writeColor(&out, colorKey, s[:k])
+			out.WriteByte(':')
+			s = bytes.TrimPrefix(s[k+1:], []byte(" "))
+			if len(s) > 0 {
+				out.WriteByte(' ')
+			}
+		}
+		switch {
+		case len(s) == 0 || string(s) == "---":
+			out.Write(s)
+		case s[0] == '|' || s[0] == '>':
+			block = indent
+			out.Write(s)
+		default:
+			writeColor(&out, yamlScalarColor(s), s)
+		}
+		out.Write(line[len(text):])
+	}
+	return out.Bytes()
+}
+
+// yamlKeyEnd returns the index of the colon that ends the key at the start
+// of s, or -1 if s does not start with a key. Plain keys have no colons
+// followed by spaces, which would be quoted.
+func yamlKeyEnd(s []byte) int {
+	start := 0
+	if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
+		start = yamlQuotedEnd(s)
+	}
+	for i := start; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ') {
+			return i
+		}
+		if start > 0 {
+			// The colon of a quoted key follows its closing quote.
+			break
+		}
+	}
+	return -1
+}
+
+// yamlQuotedEnd returns the index after the end of the double or single
+// quoted scalar at the start of s.
+func yamlQuotedEnd(s []byte) int {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case s[i] == q && q == '\'' && i+1 < len(s) && s[i+1] == q:
+			i++
+		case s[i] == q:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// yamlScalarColor returns the color of the scalar s, or none for empty
+// flow collections, tags, anchors and aliases.
+func yamlScalarColor(s []byte) string {
+	switch string(s) {
+	case "null", "~", "true", "false", ".inf", "-.inf", "+.inf", ".nan":
+		return colorLiteral
+	}
+	switch s[0] {
+	case '[', '{', '!', '&', '*':
+		return ""
+	case '-', '+', '.', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		if _, err := strconv.ParseFloat(string(s), 64); err == nil {
+			return colorNumber
+		}
+		if _, err := strconv.ParseInt(string(s), 0, 64); err == nil {
+			return colorNumber
+		}
+	}
+	return colorString
+}
+
+// writeColor writes b to out in the color, unless it is empty.
+func writeColor(out *bytes.Buffer, color string, b []byte) {
+	if color == "" || len(b) == 0 {
+		out.Write(b)
+		return
+	}
+	out.WriteString(color)
+	out.Write(b)
+	out.WriteString(colorReset)
+}
@@ -0,0 +1,145 @@
+package iocodec
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// csvEncoder writes protobuf messages as the records of a CSV file, one
+// per message, under a header record of the dotted paths of their fields,
+// e.g. account.name. The fields of nested messages have columns of their
+// own, unless their type is already being expanded, and repeated and map
+// fields, and fields of well-known types, have a single column of their
+// json. The columns follow from the type of the messages, so the records
+// of a server stream line up under the header of the first one. As in
+// tables, the elements of the only repeated message field of a message
+// whose other fields are pagination metadata, e.g. the items of a list
+// response, are the records instead. Fields that are not set have their
+// zero values. With a tab for comma, records are written as TSV, without
+// quotes, and backslashes, tabs and newlines in fields are escaped, e.g.
+// as \t, as in the text format of databases.
+type csvEncoder struct {
+	w       io.Writer
+	comma   rune
+	columns [][]string
+}
+
+func (ce *csvEncoder) Encode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot encode %T as csv", v)
+	}
+	rows, md := tableRows(proto.MessageReflect(m))
+	if md.Fields().Len() == 0 {
+		return nil
+	}
+	var records [][]string
+	if ce.columns == nil {
+		if wellKnown(md) {
+			ce.columns = [][]string{nil}
+			records = append(records, []string{string(md.Name())})
+		} else {
+			ce.columns = csvColumns(md, nil, map[protoreflect.FullName]bool{})
+			header := make([]string, len(ce.columns))
+			for i, path := range ce.columns {
+				header[i] = strings.Join(path, ".")
+			}
+			records = append(records, header)
+		}
+	}
+	opts := ProtoJSONOptions
+	opts.EmitUnpopulated = true
+	for _, r := range rows {
+		b, err := opts.Marshal(r.Interface())
+		if err != nil {
+			return err
+		}
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		var doc interface{}
+		if err := d.Decode(&doc); err != nil {
+			return err
+		}
+		record := make([]string, len(ce.columns))
+		for i, path := range ce.columns {
+			if record[i], err = csvValue(doc, path); err != nil {
+				return err
+			}
+		}
+		records = append(records, record)
+	}
+	return ce.writeRecords(records)
+}
+
+func (ce *csvEncoder) writeRecords(records [][]string) error {
+	if ce.comma != '\t' {
+		cw := csv.NewWriter(ce.w)
+		cw.Comma = ce.comma
+		return cw.WriteAll(records)
+	}
+	escape := strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	var b bytes.Buffer
+	for _, record := range records {
+		for i, f := range record {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(escape.Replace(f))
+		}
+		b.WriteByte('\n')
+	}
+	_, err := ce.w.Write(b.Bytes())
+	return err
+}
+
+// csvColumns returns the paths of the json names of the columns of the
+// fields of md, under prefix, expanding the fields of nested messages of
+// types not in parents.
+func csvColumns(md protoreflect.MessageDescriptor, prefix []string, parents map[protoreflect.FullName]bool) [][]string {
+	parents[md.FullName()] = true
+	defer delete(parents, md.FullName())
+	var columns [][]string
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := append(prefix[:len(prefix):len(prefix)], jsonName(fd))
+		if fmd := fd.Message(); fmd != nil && !fd.IsList() && !fd.IsMap() && !wellKnown(fmd) && !parents[fmd.FullName()] {
+			columns = append(columns, csvColumns(fmd, path, parents)...)
+			continue
+		}
+		columns = append(columns, path)
+	}
+	return columns
+}
+
+// csvValue returns the cell of the value at path in the decoded json doc:
+// strings and numbers as they are, objects and lists as json, and null, or
+// a value under a null message, as an empty cell.
+func csvValue(doc interface{}, path []string) (string, error) {
+	for _, k := range path {
+		o, ok := doc.(map[string]interface{})
+		if !ok {
+			return "", nil
+		}
+		doc = o[k]
+	}
+	switch v := doc.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	}
+	b, err := json.Marshal(doc)
+	return string(b), err
+}
@@ -1,21 +1,78 @@
 package iocodec
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"io"
 	"io/ioutil"
 
-	"gopkg.in/yaml.v2"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
 )
 
 // DefaultDecoders contains the default list of decoders per MIME type.
 var DefaultDecoders = DecoderGroup{
 	"xml":  DecoderMakerFunc(func(r io.Reader) Decoder { return xml.NewDecoder(r) }),
-	"json": DecoderMakerFunc(func(r io.Reader) Decoder { return json.NewDecoder(r) }),
-	"yaml": DecoderMakerFunc(func(r io.Reader) Decoder { return &yamlDecoder{r} }),
+	"json": DecoderMakerFunc(func(r io.Reader) Decoder { return &jsonDecoder{json.NewDecoder(r), false} }),
+	"ndjson": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &ndjsonDecoder{r: bufio.NewReader(r)}
+	}),
+	"yaml": DecoderMakerFunc(func(r io.Reader) Decoder { return &protoYAMLDecoder{yaml.NewDecoder(r), false} }),
+	"toml": DecoderMakerFunc(func(r io.Reader) Decoder { return &tomlDecoder{r: r} }),
+	"msgpack": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &msgpackDecoder{r: bufio.NewReader(r)}
+	}),
+	"cbor": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &cborDecoder{r: bufio.NewReader(r)}
+	}),
+	"prototext": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &prototextDecoder{r: r}
+	}),
+	"binpb": WireDecoderMaker,
 }
 
+// StrictDecoders contains decoders per MIME type that fail on unknown
+// fields, rather than ignoring them. The xml decoder is not strict, nor is
+// the binpb one, since the wire format has no field names to check.
+var StrictDecoders = DecoderGroup{
+	"xml": DecoderMakerFunc(func(r io.Reader) Decoder { return xml.NewDecoder(r) }),
+	"json": DecoderMakerFunc(func(r io.Reader) Decoder {
+		d := json.NewDecoder(r)
+		d.DisallowUnknownFields()
+		return &jsonDecoder{d, true}
+	}),
+	"ndjson": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &ndjsonDecoder{r: bufio.NewReader(r), strict: true}
+	}),
+	"yaml": DecoderMakerFunc(func(r io.Reader) Decoder {
+		d := yaml.NewDecoder(r)
+		d.KnownFields(true)
+		return &protoYAMLDecoder{d, true}
+	}),
+	"toml": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &tomlDecoder{r: r, strict: true}
+	}),
+	"msgpack": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &msgpackDecoder{r: bufio.NewReader(r), strict: true}
+	}),
+	"cbor": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &cborDecoder{r: bufio.NewReader(r), strict: true}
+	}),
+	"prototext": DecoderMakerFunc(func(r io.Reader) Decoder {
+		return &prototextDecoder{r: r, strict: true}
+	}),
+	"binpb": WireDecoderMaker,
+}
+
+// WireDecoderMaker creates Decoders that read a single protobuf message
+// in the wire format from all of their input. Decoding again returns
+// io.EOF.
+var WireDecoderMaker = DecoderMakerFunc(func(r io.Reader) Decoder { return &wireDecoder{r: r} })
+
 type (
 	// A Decoder decodes data into v.
 	Decoder interface {
@@ -33,6 +90,9 @@ type (
 	// DecoderMakerFunc is an adapter for creating DecoderMakers
 	// from functions.
 	DecoderMakerFunc func(r io.Reader) Decoder
+
+	// DecoderFunc is an adapter for creating Decoders from functions.
+	DecoderFunc func(v interface{}) error
 )
 
 // NewDecoder implements the DecoderMaker interface.
@@ -40,14 +100,87 @@ func (f DecoderMakerFunc) NewDecoder(r io.Reader) Decoder {
 	return f(r)
 }
 
-type yamlDecoder struct {
-	r io.Reader
+// Decode implements the Decoder interface.
+func (f DecoderFunc) Decode(v interface{}) error {
+	return f(v)
+}
+
+// jsonDecoder decodes protobuf messages with protojson, in the JSON mapping
+// of proto3, so well-known types such as google.protobuf.Struct and Value
+// accept arbitrary JSON, and 64-bit integers may be numbers or strings and
+// are decoded without loss of precision. Other values are decoded with
+// encoding/json.
+type jsonDecoder struct {
+	d      *json.Decoder
+	strict bool
+}
+
+func (jd *jsonDecoder) Decode(v interface{}) error {
+	if m, ok := v.(proto.Message); ok {
+		var raw json.RawMessage
+		if err := jd.d.Decode(&raw); err != nil {
+			return err
+		}
+		u := protojson.UnmarshalOptions{DiscardUnknown: !jd.strict}
+		return u.Unmarshal(raw, proto.MessageV2(m))
+	}
+	return jd.d.Decode(v)
+}
+
+// ndjsonDecoder decodes newline delimited json, one value per line, as
+// jsonDecoder does, so values must not span lines, and errors have the
+// number of their line. Blank lines are skipped.
+type ndjsonDecoder struct {
+	r      *bufio.Reader
+	line   int
+	strict bool
+}
+
+func (nd *ndjsonDecoder) Decode(v interface{}) error {
+	for {
+		b, err := nd.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(bytes.TrimSpace(b)) == 0 {
+			if err != nil {
+				return err
+			}
+			nd.line++
+			continue
+		}
+		nd.line++
+		d := json.NewDecoder(bytes.NewReader(b))
+		if nd.strict {
+			d.DisallowUnknownFields()
+		}
+		if err := (&jsonDecoder{d, nd.strict}).Decode(v); err != nil {
+			return fmt.Errorf("line %d: %v", nd.line, err)
+		}
+		if d.More() {
+			return fmt.Errorf("line %d: more than one json value", nd.line)
+		}
+		return nil
+	}
 }
 
-func (yd *yamlDecoder) Decode(v interface{}) error {
-	b, err := ioutil.ReadAll(yd.r)
+type wireDecoder struct {
+	r    io.Reader
+	done bool
+}
+
+func (wd *wireDecoder) Decode(v interface{}) error {
+	if wd.done {
+		return io.EOF
+	}
+	wd.done = true
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot decode the wire format into %T", v)
+	}
+	b, err := ioutil.ReadAll(wd.r)
 	if err != nil {
 		return err
 	}
-	return yaml.Unmarshal(b, v)
+	return proto.Unmarshal(b, m)
 }
@@ -0,0 +1,50 @@
+package iocodec
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestJSONDecoderStructField(t *testing.T) {
+	mt := testRecordType(t)
+	labels := mt.Descriptor().Fields().ByName("labels")
+	want, err := structpb.NewStruct(map[string]interface{}{
+		"env":  "prod",
+		"tags": []interface{}{"a", 1.5, true, nil},
+		"limits": map[string]interface{}{
+			"cpu": 2,
+			"nested": map[string]interface{}{
+				"deep":  []interface{}{map[string]interface{}{"ok": false}},
+				"empty": map[string]interface{}{},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := `{"id": "1", "labels": {"env": "prod", "tags": ["a", 1.5, true, null], "limits": {"cpu": 2, "nested": {"deep": [{"ok": false}], "empty": {}}}}}
+{"labels": {}}`
+	for name, group := range map[string]DecoderGroup{"default": DefaultDecoders, "strict": StrictDecoders} {
+		d := group["json"].NewDecoder(strings.NewReader(in))
+		m := mt.New().Interface()
+		if err := d.Decode(m); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got := m.ProtoReflect().Get(labels).Message().Interface()
+		if !proto.Equal(got, want) {
+			t.Errorf("%s: decoded labels %v, want %v", name, got, want)
+		}
+		// The next document of the stream is decoded on its own.
+		m = mt.New().Interface()
+		if err := d.Decode(m); err != nil {
+			t.Fatalf("%s: second document: %v", name, err)
+		}
+		got = m.ProtoReflect().Get(labels).Message().Interface()
+		if !m.ProtoReflect().Has(labels) || !proto.Equal(got, &structpb.Struct{}) {
+			t.Errorf("%s: second document: decoded labels %v, want empty", name, got)
+		}
+	}
+}
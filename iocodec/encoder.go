@@ -4,17 +4,58 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"io"
+	"reflect"
+	"strings"
 
-	"gopkg.in/yaml.v2"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
 )
 
 // DefaultEncoders contains the default list of encoders per MIME type.
 var DefaultEncoders = EncoderGroup{
 	"xml":        EncoderMakerFunc(func(w io.Writer) Encoder { return &xmlEncoder{w} }),
-	"json":       EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, false} }),
-	"prettyjson": EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, true} }),
-	"yaml":       EncoderMakerFunc(func(w io.Writer) Encoder { return &yamlEncoder{w} }),
+	"json":       EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, false, &ProtoJSONOptions} }),
+	"prettyjson": EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, true, &ProtoJSONOptions} }),
+	"ndjson":     EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, false, &ProtoJSONOptions} }),
+	"yaml":       EncoderMakerFunc(func(w io.Writer) Encoder { return newProtoYAMLEncoder(w, &ProtoJSONOptions) }),
+	"toml":       EncoderMakerFunc(func(w io.Writer) Encoder { return &tomlEncoder{w, &ProtoJSONOptions, "response"} }),
+	"msgpack":    EncoderMakerFunc(func(w io.Writer) Encoder { return &msgpackEncoder{w, &ProtoJSONOptions} }),
+	"cbor":       EncoderMakerFunc(func(w io.Writer) Encoder { return &cborEncoder{w, &ProtoJSONOptions} }),
+	"prototext":  EncoderMakerFunc(func(w io.Writer) Encoder { return &prototextEncoder{w} }),
+	"binpb":      EncoderMakerFunc(func(w io.Writer) Encoder { return &wireEncoder{w} }),
+	"table":      EncoderMakerFunc(func(w io.Writer) Encoder { return &tableEncoder{w: w} }),
+	"csv":        EncoderMakerFunc(func(w io.Writer) Encoder { return &csvEncoder{w: w, comma: ','} }),
+	"tsv":        EncoderMakerFunc(func(w io.Writer) Encoder { return &csvEncoder{w: w, comma: '\t'} }),
+}
+
+// ProtoJSONOptions are the options the json and yaml encoders of
+// DefaultEncoders and ColorEncoders, and Flatten, encode protobuf
+// messages with, in the JSON mapping of proto3, so enums have their
+// names, 64-bit integers are strings, and well-known types have their
+// canonical form, e.g. an RFC 3339 string for a Timestamp. Fields have
+// their proto names by default, as the json of Go structs generated from
+// protos does.
+var ProtoJSONOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// sampleJSONOptions are the options of the json encoders of
+// ProtoJSONEncoders, which ProtoJSONOptions don't change.
+var sampleJSONOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// ProtoJSONEncoders contains json, yaml, toml, msgpack and cbor encoders
+// that encode protobuf messages in the JSON mapping of proto3, as
+// ProtoJSONOptions do by default, regardless of their changes. Other
+// values are encoded as the DefaultEncoders do.
+var ProtoJSONEncoders = EncoderGroup{
+	"json":       EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, false, &sampleJSONOptions} }),
+	"prettyjson": EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, true, &sampleJSONOptions} }),
+	"ndjson":     EncoderMakerFunc(func(w io.Writer) Encoder { return &jsonEncoder{w, false, &sampleJSONOptions} }),
+	"yaml":       EncoderMakerFunc(func(w io.Writer) Encoder { return newProtoYAMLEncoder(w, &sampleJSONOptions) }),
+	"toml":       EncoderMakerFunc(func(w io.Writer) Encoder { return &tomlEncoder{w, &sampleJSONOptions, ""} }),
+	"msgpack":    EncoderMakerFunc(func(w io.Writer) Encoder { return &msgpackEncoder{w, &sampleJSONOptions} }),
+	"cbor":       EncoderMakerFunc(func(w io.Writer) Encoder { return &cborEncoder{w, &sampleJSONOptions} }),
 }
 
 type (
@@ -34,6 +75,9 @@ type (
 	// EncoderMakerFunc is an adapter for creating EncoderMakers
 	// from functions.
 	EncoderMakerFunc func(w io.Writer) Encoder
+
+	// EncoderFunc is an adapter for creating Encoders from functions.
+	EncoderFunc func(v interface{}) error
 )
 
 // NewEncoder implements the EncoderMaker interface.
@@ -41,6 +85,11 @@ func (f EncoderMakerFunc) NewEncoder(w io.Writer) Encoder {
 	return f(w)
 }
 
+// Encode implements the Encoder interface.
+func (f EncoderFunc) Encode(v interface{}) error {
+	return f(v)
+}
+
 type xmlEncoder struct {
 	w io.Writer
 }
@@ -53,41 +102,125 @@ func (xe *xmlEncoder) Encode(v interface{}) error {
 	return e.Encode(v)
 }
 
+// jsonEncoder encodes protobuf messages with protojson and the options
+// opts, and other values with encoding/json.
 type jsonEncoder struct {
 	w      io.Writer
 	pretty bool
+	opts   *protojson.MarshalOptions
 }
 
 func (je *jsonEncoder) Encode(v interface{}) error {
+	b, err := marshalJSON(v, *je.opts)
+	if err != nil {
+		return err
+	}
+	// The spacing of protojson is unstable on purpose, so it is redone.
+	var out bytes.Buffer
 	if je.pretty {
-		b, err := json.Marshal(v)
-		if err != nil {
-			return err
-		}
-		var out bytes.Buffer
 		err = json.Indent(&out, b, "", "\t")
-		if err != nil {
-			return err
-		}
-		_, err = io.Copy(je.w, &out)
-		if err != nil {
-			return err
-		}
-		_, err = je.w.Write([]byte("\n"))
+	} else {
+		err = json.Compact(&out, b)
+	}
+	if err != nil {
 		return err
 	}
-	return json.NewEncoder(je.w).Encode(v)
+	out.WriteByte('\n')
+	_, err = je.w.Write(out.Bytes())
+	return err
 }
 
+// marshalJSON returns the json of v, encoded with protojson and the
+// options opts if v is a protobuf message, or else with encoding/json.
+func marshalJSON(v interface{}, opts protojson.MarshalOptions) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return opts.Marshal(proto.MessageV2(m))
+	}
+	return json.Marshal(v)
+}
+
+// yamlEncoder writes each value as a document of a YAML stream, so the
+// responses of a server stream can be decoded back one by one.
 type yamlEncoder struct {
-	w io.Writer
+	e *yaml.Encoder
+}
+
+func newYAMLEncoder(w io.Writer) *yamlEncoder {
+	e := yaml.NewEncoder(w)
+	e.SetIndent(2)
+	return &yamlEncoder{e}
 }
 
 func (ye *yamlEncoder) Encode(v interface{}) error {
-	b, err := yaml.Marshal(v)
+	return ye.e.Encode(v)
+}
+
+// wireEncoder writes protobuf messages in the wire format, without any
+// framing, so it is read back by WireDecoderMaker. The messages of a
+// server stream are written one after another, and read back merged.
+type wireEncoder struct {
+	w io.Writer
+}
+
+func (we *wireEncoder) Encode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot encode %T in the wire format", v)
+	}
+	b, err := proto.Marshal(m)
 	if err != nil {
 		return err
 	}
-	_, err = ye.w.Write(b)
+	_, err = we.w.Write(b)
 	return err
 }
+
+// RawFieldEncoderMaker returns an EncoderMaker for encoders that write
+// the raw contents of the named bytes or string field of each value,
+// without any framing. The field is matched by its proto name, json name,
+// or Go struct field name.
+func RawFieldEncoderMaker(field string) EncoderMaker {
+	return EncoderMakerFunc(func(w io.Writer) Encoder { return &rawFieldEncoder{w, field} })
+}
+
+type rawFieldEncoder struct {
+	w     io.Writer
+	field string
+}
+
+func (re *rawFieldEncoder) Encode(v interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("raw field %q: not a message: %T", re.field, v)
+	}
+	f, ok := fieldByName(rv, re.field)
+	if !ok {
+		return fmt.Errorf("raw field %q: not found in %T", re.field, v)
+	}
+	switch {
+	case f.Kind() == reflect.String:
+		_, err := io.WriteString(re.w, f.String())
+		return err
+	case f.Kind() == reflect.Slice && f.Type().Elem().Kind() == reflect.Uint8:
+		_, err := re.w.Write(f.Bytes())
+		return err
+	}
+	return fmt.Errorf("raw field %q: not bytes or string: %s", re.field, f.Type())
+}
+
+// fieldByName returns the struct field of v that matches name.
+func fieldByName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Name == name {
+			return v.Field(i), true
+		}
+		for _, opt := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+			if opt == "name="+name || opt == "json="+name {
+				return v.Field(i), true
+			}
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,135 @@
+package iocodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A FlatField is a leaf value of a flattened message, keyed by the dotted
+// path of its field.
+type FlatField struct {
+	Key   string
+	Value interface{}
+}
+
+// FlatMessage is a flattened message, with its fields in the order they
+// are encoded in json. It is encoded in json and yaml as an object.
+type FlatMessage []FlatField
+
+// Flatten returns the leaf values of v, as it is encoded in json, keyed
+// by the dotted paths of their fields, e.g. account.name. Elements of
+// repeated fields are keyed by their index, e.g. items.0.name. Empty
+// messages and lists have no leaf values, and are left out.
+func Flatten(v interface{}) (FlatMessage, error) {
+	b, err := marshalJSON(v, ProtoJSONOptions)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var fm FlatMessage
+	if err := fm.flatten(d, ""); err != nil {
+		return nil, fmt.Errorf("flatten: %v", err)
+	}
+	return fm, nil
+}
+
+// flatten appends the leaf values of the next json value in d to fm,
+// under the key prefix.
+func (fm *FlatMessage) flatten(d *json.Decoder, prefix string) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	key := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+	switch t {
+	case json.Delim('{'):
+		for d.More() {
+			k, err := d.Token()
+			if err != nil {
+				return err
+			}
+			if err := fm.flatten(d, key(k.(string))); err != nil {
+				return err
+			}
+		}
+		_, err = d.Token()
+		return err
+	case json.Delim('['):
+		for i := 0; d.More(); i++ {
+			if err := fm.flatten(d, key(strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		_, err = d.Token()
+		return err
+	}
+	*fm = append(*fm, FlatField{prefix, t})
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (fm FlatMessage) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range fm {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (fm FlatMessage) MarshalYAML() (interface{}, error) {
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	for _, f := range fm {
+		var v yaml.Node
+		if num, ok := f.Value.(json.Number); ok {
+			// Plain numbers, rather than the quoted strings of
+			// json.Number values.
+			v = yaml.Node{Kind: yaml.ScalarNode, Value: num.String()}
+		} else if err := v.Encode(f.Value); err != nil {
+			return nil, err
+		}
+		n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: f.Key}, &v)
+	}
+	return n, nil
+}
+
+// FlattenEncoderMaker returns an EncoderMaker for encoders that flatten
+// each value, and encode the FlatMessage with the encoders of em.
+func FlattenEncoderMaker(em EncoderMaker) EncoderMaker {
+	return EncoderMakerFunc(func(w io.Writer) Encoder {
+		e := em.NewEncoder(w)
+		return EncoderFunc(func(v interface{}) error {
+			fm, err := Flatten(v)
+			if err != nil {
+				return err
+			}
+			return e.Encode(fm)
+		})
+	})
+}
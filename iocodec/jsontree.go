@@ -0,0 +1,102 @@
+package iocodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// jsonObject is a decoded json object that keeps the order of its keys.
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	Key   string
+	Value interface{}
+}
+
+// jsonTree returns the json of v, encoded with protojson and the options
+// opts if v is a protobuf message, or else with encoding/json, decoded
+// into nil, bool, json.Number, string, []interface{} and jsonObject
+// values, for the encoders of formats with the data model of json.
+func jsonTree(v interface{}, opts protojson.MarshalOptions) (interface{}, error) {
+	b, err := marshalJSON(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return readJSONTree(d)
+}
+
+func readJSONTree(d *json.Decoder) (interface{}, error) {
+	t, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case json.Delim('{'):
+		o := jsonObject{}
+		for d.More() {
+			k, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := readJSONTree(d)
+			if err != nil {
+				return nil, err
+			}
+			o = append(o, jsonMember{k.(string), v})
+		}
+		_, err = d.Token()
+		return o, err
+	case json.Delim('['):
+		a := []interface{}{}
+		for d.More() {
+			v, err := readJSONTree(d)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		_, err = d.Token()
+		return a, err
+	}
+	return t, nil
+}
+
+// jsonNumber returns n as an int64, a uint64 if it is too large for an
+// int64, or else a float64.
+func jsonNumber(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return u
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// decodeTree decodes doc, a value decoded from a format with the data
+// model of json, into v: with protojson if v is a protobuf message, as
+// protoYAMLDecoder does, or else with encoding/json.
+func decodeTree(doc interface{}, v interface{}, strict bool) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		b, err := json.Marshal(yamlToJSON(doc, nil))
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, v)
+	}
+	mr := proto.MessageReflect(m)
+	b, err := json.Marshal(yamlToJSON(doc, mr.Descriptor()))
+	if err != nil {
+		return err
+	}
+	u := protojson.UnmarshalOptions{DiscardUnknown: !strict}
+	return u.Unmarshal(b, mr.Interface())
+}
@@ -0,0 +1,80 @@
+package iocodec
+
+import (
+	"mime"
+	"strings"
+)
+
+// MIMEAliases maps common MIME types to the names of the encoders and
+// decoders in the groups of this package, so formats can be selected the
+// way HTTP tools do, e.g. application/json for json.
+var MIMEAliases = map[string]string{
+	"application/json":          "json",
+	"text/json":                 "json",
+	"application/yaml":          "yaml",
+	"application/x-yaml":        "yaml",
+	"text/yaml":                 "yaml",
+	"text/x-yaml":               "yaml",
+	"application/xml":           "xml",
+	"text/xml":                  "xml",
+	"application/x-protobuf":    "binpb",
+	"application/protobuf":      "binpb",
+	"application/x-ndjson":      "ndjson",
+	"application/jsonl":         "ndjson",
+	"application/toml":          "toml",
+	"application/msgpack":       "msgpack",
+	"application/x-msgpack":     "msgpack",
+	"application/cbor":          "cbor",
+	"text/csv":                  "csv",
+	"text/tab-separated-values": "tsv",
+}
+
+// ExtensionAliases maps file name extensions to the names of the encoders
+// and decoders in the groups of this package, e.g. textpb for prototext.
+var ExtensionAliases = map[string]string{
+	"yml":    "yaml",
+	"textpb": "prototext",
+	"txtpb":  "prototext",
+	"pb":     "binpb",
+	"jsonl":  "ndjson",
+}
+
+// alias returns the name ExtensionAliases maps the extension t to, or
+// MIMEAliases the MIME type t, ignoring its case and parameters, e.g.
+// application/json; charset=utf-8.
+func alias(t string) (string, bool) {
+	if name, ok := ExtensionAliases[strings.ToLower(t)]; ok {
+		return name, true
+	}
+	if mt, _, err := mime.ParseMediaType(t); err == nil {
+		t = mt
+	}
+	name, ok := MIMEAliases[strings.ToLower(t)]
+	return name, ok
+}
+
+// Lookup returns the EncoderMaker of name, or of its alias in
+// ExtensionAliases or MIMEAliases.
+func (g EncoderGroup) Lookup(name string) (EncoderMaker, bool) {
+	if em, ok := g[name]; ok {
+		return em, true
+	}
+	if a, ok := alias(name); ok {
+		em, ok := g[a]
+		return em, ok
+	}
+	return nil, false
+}
+
+// Lookup returns the DecoderMaker of name, or of its alias in
+// ExtensionAliases or MIMEAliases.
+func (g DecoderGroup) Lookup(name string) (DecoderMaker, bool) {
+	if dm, ok := g[name]; ok {
+		return dm, true
+	}
+	if a, ok := alias(name); ok {
+		dm, ok := g[a]
+		return dm, ok
+	}
+	return nil, false
+}
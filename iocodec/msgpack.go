@@ -0,0 +1,286 @@
+package iocodec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// msgpackEncoder writes protobuf messages as MessagePack maps of their
+// JSON mapping of proto3, with the options opts, so 64-bit integers are
+// strings, and bytes base64 strings, as in json. Other values are written
+// as they are encoded in json.
+type msgpackEncoder struct {
+	w    io.Writer
+	opts *protojson.MarshalOptions
+}
+
+func (me *msgpackEncoder) Encode(v interface{}) error {
+	t, err := jsonTree(v, *me.opts)
+	if err != nil {
+		return err
+	}
+	_, err = me.w.Write(appendMsgpack(nil, t))
+	return err
+}
+
+// appendMsgpack appends the MessagePack encoding of the json tree value v
+// to b.
+func appendMsgpack(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if v {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case json.Number:
+		switch n := jsonNumber(v).(type) {
+		case int64:
+			if n >= 0 {
+				return appendMsgpackUint(b, uint64(n))
+			}
+			return appendMsgpackInt(b, n)
+		case uint64:
+			return appendMsgpackUint(b, n)
+		case float64:
+			return appendUint(append(b, 0xcb), math.Float64bits(n), 8)
+		}
+	case string:
+		b = appendMsgpackHead(b, len(v), 0xa0, 32, 0xd9, 0xda, 0xdb)
+		return append(b, v...)
+	case []interface{}:
+		b = appendMsgpackHead(b, len(v), 0x90, 16, 0, 0xdc, 0xdd)
+		for _, e := range v {
+			b = appendMsgpack(b, e)
+		}
+		return b
+	case jsonObject:
+		b = appendMsgpackHead(b, len(v), 0x80, 16, 0, 0xde, 0xdf)
+		for _, m := range v {
+			b = appendMsgpack(b, m.Key)
+			b = appendMsgpack(b, m.Value)
+		}
+		return b
+	}
+	panic(fmt.Sprintf("msgpack: unexpected json value %T", v))
+}
+
+// appendMsgpackHead appends the head of a string, array or map of n
+// elements to b: fix|n if n is under fixLen, or else the first of the
+// types t8, unless it is zero, t16 and t32 that holds n, followed by n.
+func appendMsgpackHead(b []byte, n int, fix byte, fixLen int, t8, t16, t32 byte) []byte {
+	switch {
+	case n < fixLen:
+		return append(b, fix|byte(n))
+	case t8 != 0 && n <= math.MaxUint8:
+		return append(b, t8, byte(n))
+	case n <= math.MaxUint16:
+		return appendUint(append(b, t16), uint64(n), 2)
+	}
+	return appendUint(append(b, t32), uint64(n), 4)
+}
+
+func appendMsgpackUint(b []byte, n uint64) []byte {
+	switch {
+	case n < 0x80:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		return appendUint(append(b, 0xcd), n, 2)
+	case n <= math.MaxUint32:
+		return appendUint(append(b, 0xce), n, 4)
+	}
+	return appendUint(append(b, 0xcf), n, 8)
+}
+
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return appendUint(append(b, 0xd1), uint64(n), 2)
+	case n >= math.MinInt32:
+		return appendUint(append(b, 0xd2), uint64(n), 4)
+	}
+	return appendUint(append(b, 0xd3), uint64(n), 8)
+}
+
+// appendUint appends the size low bytes of n to b, in big endian order.
+func appendUint(b []byte, n uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		b = append(b, byte(n>>(8*uint(i))))
+	}
+	return b
+}
+
+// msgpackDecoder reads a stream of MessagePack values, one per Decode,
+// and decodes protobuf messages from them with protojson, as if they were
+// json, so fields may have their proto or json_name names. Keys of maps
+// that are not strings are written in decimal, and extension types are
+// not supported.
+type msgpackDecoder struct {
+	r      *bufio.Reader
+	strict bool
+}
+
+func (md *msgpackDecoder) Decode(v interface{}) error {
+	if _, err := md.r.Peek(1); err != nil {
+		return err
+	}
+	doc, err := readMsgpack(md.r)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return fmt.Errorf("msgpack: %v", err)
+	}
+	return decodeTree(doc, v, md.strict)
+}
+
+func readMsgpack(r *bufio.Reader) (interface{}, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c < 0x80:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == 0x80:
+		return readMsgpackMap(r, int(c&0x0f))
+	case c&0xf0 == 0x90:
+		return readMsgpackArray(r, int(c&0x0f))
+	case c&0xe0 == 0xa0:
+		b, err := readN(r, int(c&0x1f))
+		return string(b), err
+	}
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readUint(r, 1<<(c-0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return readN(r, int(n))
+	case 0xca:
+		n, err := readUint(r, 4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := readUint(r, 8)
+		return math.Float64frombits(n), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return readUint(r, 1<<(c-0xcc))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		n, err := readUint(r, size)
+		// Sign extend the n of size bytes.
+		shift := uint(64 - 8*size)
+		return int64(n<<shift) >> shift, err
+	case 0xd9, 0xda, 0xdb:
+		n, err := readUint(r, 1<<(c-0xd9))
+		if err != nil {
+			return nil, err
+		}
+		b, err := readN(r, int(n))
+		return string(b), err
+	case 0xdc, 0xdd:
+		n, err := readUint(r, 2<<(c-0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackArray(r, int(n))
+	case 0xde, 0xdf:
+		n, err := readUint(r, 2<<(c-0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgpackMap(r, int(n))
+	}
+	return nil, fmt.Errorf("unsupported type 0x%02x", c)
+}
+
+func readMsgpackArray(r *bufio.Reader, n int) (interface{}, error) {
+	a := make([]interface{}, 0, capHint(n))
+	for i := 0; i < n; i++ {
+		e, err := readMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, e)
+	}
+	return a, nil
+}
+
+func readMsgpackMap(r *bufio.Reader, n int) (interface{}, error) {
+	m := make(map[string]interface{}, capHint(n))
+	for i := 0; i < n; i++ {
+		k, err := readMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+		e, err := readMsgpack(r)
+		if err != nil {
+			return nil, err
+		}
+		m[mapKey(k)] = e
+	}
+	return m, nil
+}
+
+// mapKey returns the decoded map key k as a string, in decimal if it is
+// a number.
+func mapKey(k interface{}) string {
+	if b, ok := k.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(k)
+}
+
+// capHint limits the capacity preallocated for n elements, which come
+// from the input, to a sane size.
+func capHint(n int) int {
+	if n > 1024 {
+		return 1024
+	}
+	return n
+}
+
+// readUint reads a big endian unsigned integer of size bytes from r.
+func readUint(r io.Reader, size int) (uint64, error) {
+	b, err := readN(r, size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+// readN reads n bytes from r, which are not allocated until they are
+// read, since n comes from the input.
+func readN(r io.Reader, n int) ([]byte, error) {
+	var b bytes.Buffer
+	_, err := io.CopyN(&b, r, int64(n))
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return b.Bytes(), err
+}
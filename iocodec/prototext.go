@@ -0,0 +1,54 @@
+package iocodec
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/prototext"
+)
+
+// prototextEncoder writes protobuf messages in the multiline protobuf text
+// format.
+type prototextEncoder struct {
+	w io.Writer
+}
+
+func (pe *prototextEncoder) Encode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot encode %T in the protobuf text format", v)
+	}
+	b, err := prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(proto.MessageV2(m))
+	if err != nil {
+		return err
+	}
+	_, err = pe.w.Write(b)
+	return err
+}
+
+// prototextDecoder reads a single protobuf message in the text format from
+// all of its input. Decoding again returns io.EOF.
+type prototextDecoder struct {
+	r      io.Reader
+	strict bool
+	done   bool
+}
+
+func (pd *prototextDecoder) Decode(v interface{}) error {
+	if pd.done {
+		return io.EOF
+	}
+	pd.done = true
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot decode the protobuf text format into %T", v)
+	}
+	b, err := ioutil.ReadAll(pd.r)
+	if err != nil {
+		return err
+	}
+	u := prototext.UnmarshalOptions{DiscardUnknown: !pd.strict}
+	return u.Unmarshal(b, proto.MessageV2(m))
+}
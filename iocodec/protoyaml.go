@@ -0,0 +1,167 @@
+package iocodec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// protoYAMLEncoder writes protobuf messages as YAML documents of their
+// JSON mapping of proto3, with the options opts, so that they can be read
+// back by protoYAMLDecoder. Other values are encoded as yamlEncoder does.
+type protoYAMLEncoder struct {
+	yamlEncoder
+	opts *protojson.MarshalOptions
+}
+
+func newProtoYAMLEncoder(w io.Writer, opts *protojson.MarshalOptions) *protoYAMLEncoder {
+	return &protoYAMLEncoder{*newYAMLEncoder(w), opts}
+}
+
+func (pe *protoYAMLEncoder) Encode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return pe.yamlEncoder.Encode(v)
+	}
+	b, err := pe.opts.Marshal(proto.MessageV2(m))
+	if err != nil {
+		return err
+	}
+	// JSON is YAML, so decoding it into a node keeps the order of the
+	// fields; the node is then written in block style.
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	blockStyle(&n)
+	return pe.e.Encode(&n)
+}
+
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// protoYAMLDecoder decodes each YAML document into a protobuf message as
+// if it were JSON, with protojson, so fields may have their proto or
+// json_name names and well-known types their canonical forms. The
+// lowercased Go field names of earlier versions are accepted as well.
+// Other values are decoded with yaml.v3.
+type protoYAMLDecoder struct {
+	d      *yaml.Decoder
+	strict bool
+}
+
+func (pd *protoYAMLDecoder) Decode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return pd.d.Decode(v)
+	}
+	var doc interface{}
+	if err := pd.d.Decode(&doc); err != nil {
+		return err
+	}
+	mr := proto.MessageReflect(m)
+	b, err := json.Marshal(yamlToJSON(doc, mr.Descriptor()))
+	if err != nil {
+		return err
+	}
+	u := protojson.UnmarshalOptions{DiscardUnknown: !pd.strict}
+	return u.Unmarshal(b, mr.Interface())
+}
+
+// yamlToJSON converts the decoded YAML value v of a message of type md,
+// or of no message if md is nil, to a value encoding/json can marshal:
+// keys of maps become strings, times RFC 3339 strings, and infinities and
+// NaN the strings of the JSON mapping. Fields named after their lowercased
+// Go names are renamed to their proto names.
+func yamlToJSON(v interface{}, md protoreflect.MessageDescriptor) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		o := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			fd := messageField(md, k)
+			if fd != nil {
+				k = string(fd.Name())
+			}
+			o[k] = fieldToJSON(e, fd)
+		}
+		return o
+	case map[interface{}]interface{}:
+		o := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			o[fmt.Sprint(k)] = yamlToJSON(e, nil)
+		}
+		return o
+	case []interface{}:
+		o := make([]interface{}, len(v))
+		for i, e := range v {
+			o[i] = yamlToJSON(e, md)
+		}
+		return o
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		switch {
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		case math.IsNaN(v):
+			return "NaN"
+		}
+	}
+	return v
+}
+
+// messageField returns the field of md named k, by its proto, json or
+// lowercased Go name, or nil if there is none, or md is a well-known type,
+// whose JSON forms have no fields of their own.
+func messageField(md protoreflect.MessageDescriptor, k string) protoreflect.FieldDescriptor {
+	if md == nil || md.ParentFile().Package() == "google.protobuf" {
+		return nil
+	}
+	fields := md.Fields()
+	if fd := fields.ByName(protoreflect.Name(k)); fd != nil {
+		return fd
+	}
+	if fd := fields.ByJSONName(k); fd != nil {
+		return fd
+	}
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if strings.ToLower(strings.Replace(string(fd.Name()), "_", "", -1)) == k {
+			return fd
+		}
+	}
+	return nil
+}
+
+// fieldToJSON converts the decoded YAML value v of the field fd, which may
+// be nil, as yamlToJSON does.
+func fieldToJSON(v interface{}, fd protoreflect.FieldDescriptor) interface{} {
+	switch {
+	case fd == nil:
+		return yamlToJSON(v, nil)
+	case fd.IsMap():
+		entries, ok := yamlToJSON(v, nil).(map[string]interface{})
+		if !ok {
+			return yamlToJSON(v, nil)
+		}
+		for k, e := range entries {
+			entries[k] = yamlToJSON(e, fd.MapValue().Message())
+		}
+		return entries
+	}
+	return yamlToJSON(v, fd.Message())
+}
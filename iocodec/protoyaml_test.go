@@ -0,0 +1,137 @@
+package iocodec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testField returns an optional field of a test message. The type of a
+// message or enum field is typeName.
+func testField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:   typ.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+// testFile returns a proto3 file of the package pkg holding msgs, whose
+// fields may have the types of the files named by deps.
+func testFile(t *testing.T, pkg string, deps []string, msgs ...*descriptorpb.DescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(strings.Replace(pkg, ".", "_", -1) + ".proto"),
+		Package:     proto.String(pkg),
+		Syntax:      proto.String("proto3"),
+		Dependency:  deps,
+		MessageType: msgs,
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+// testRecordType returns the type of a message with fields of the kinds
+// whose JSON mapping differs from their Go values: 64-bit integers,
+// bytes, enums and well-known types.
+func testRecordType(t *testing.T) protoreflect.MessageType {
+	t.Helper()
+	fd := testFile(t, "iocodec.test", []string{"google/protobuf/struct.proto", "google/protobuf/timestamp.proto"}, &descriptorpb.DescriptorProto{
+		Name: proto.String("Record"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+			testField("count", 2, descriptorpb.FieldDescriptorProto_TYPE_UINT64, ""),
+			testField("data", 3, descriptorpb.FieldDescriptorProto_TYPE_BYTES, ""),
+			testField("kind", 4, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".iocodec.test.Record.Kind"),
+			testField("at", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+			testField("labels", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Struct"),
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Kind"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("KIND_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("CHECK"), Number: proto.Int32(1)},
+			},
+		}},
+	})
+	return dynamicpb.NewMessageType(fd.Messages().ByName("Record"))
+}
+
+// testRecord returns a message of type mt decoded from the json s.
+func testRecord(t *testing.T, mt protoreflect.MessageType, s string) proto.Message {
+	t.Helper()
+	m := mt.New().Interface()
+	if err := protojson.Unmarshal([]byte(s), m); err != nil {
+		t.Fatalf("unmarshal %s: %v", s, err)
+	}
+	return m
+}
+
+func TestYAMLEncoderWritesJSONMapping(t *testing.T) {
+	mt := testRecordType(t)
+	m := testRecord(t, mt, `{"id": "7", "data": "Ynl0ZXM=", "kind": "CHECK", "at": "2006-01-02T15:04:05Z"}`)
+	var b bytes.Buffer
+	if err := DefaultEncoders["yaml"].NewEncoder(&b).Encode(m); err != nil {
+		t.Fatal(err)
+	}
+	want := "id: \"7\"\ndata: Ynl0ZXM=\nkind: CHECK\nat: \"2006-01-02T15:04:05Z\"\n"
+	if b.String() != want {
+		t.Errorf("yaml response:\n%s\nwant:\n%s", b.String(), want)
+	}
+	got := mt.New().Interface()
+	if err := DefaultDecoders["yaml"].NewDecoder(&b).Decode(got); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, m) {
+		t.Errorf("decoded %v, want %v", got, m)
+	}
+}
+
+func TestYAMLEncoderRoundTripsInt64(t *testing.T) {
+	mt := testRecordType(t)
+	for _, tc := range []struct{ field, value string }{
+		{"id", "9007199254740991"},
+		{"id", "9007199254740992"},
+		{"id", "9007199254740993"},
+		{"id", "-9007199254740993"},
+		{"id", "9223372036854775807"},
+		{"id", "-9223372036854775808"},
+		{"count", "9007199254740993"},
+		{"count", "18446744073709551615"},
+	} {
+		m := testRecord(t, mt, `{"`+tc.field+`": "`+tc.value+`"}`)
+		var b bytes.Buffer
+		e := DefaultEncoders["yaml"].NewEncoder(&b)
+		if err := e.Encode(m); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(b.String(), tc.value) {
+			t.Errorf("%s %s: yaml response %q lost its digits", tc.field, tc.value, b.String())
+		}
+		got := mt.New().Interface()
+		if err := DefaultDecoders["yaml"].NewDecoder(&b).Decode(got); err != nil {
+			t.Fatalf("%s %s: %v", tc.field, tc.value, err)
+		}
+		if !proto.Equal(got, m) {
+			t.Errorf("%s %s: decoded %v, want %v", tc.field, tc.value, got, m)
+		}
+	}
+}
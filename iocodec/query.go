@@ -0,0 +1,200 @@
+package iocodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Query selects values from the json of responses, with a subset of jq
+// and JSONPath: .name selects a field, [0] an element, counting from the
+// end if negative, [] or [*] all the elements of a list or the values of
+// an object, and . alone the whole response, e.g. .items[].name. A
+// leading $ is ignored, and ["name"] selects fields with dots in their
+// names. Fields of protobuf messages are selected by their names in the
+// json of ProtoJSONOptions, and fields that are not set have their zero
+// values.
+type Query []queryStep
+
+type queryStep struct {
+	all   bool
+	field *string
+	index int
+}
+
+// ParseQuery parses the query q.
+func ParseQuery(q string) (Query, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(q), "$")
+	if s == "" || s == "." {
+		return Query{}, nil
+	}
+	var query Query
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, "[]"), strings.HasPrefix(s, "[*]"):
+			query = append(query, queryStep{all: true})
+			s = s[strings.Index(s, "]")+1:]
+		case strings.HasPrefix(s, "[\""):
+			end := strings.Index(s, "\"]")
+			if end < 0 {
+				return nil, fmt.Errorf("query %q: unterminated field name", q)
+			}
+			name, err := strconv.Unquote(s[1 : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("query %q: invalid field name: %s", q, s[1:end+1])
+			}
+			query = append(query, queryStep{field: &name})
+			s = s[end+2:]
+		case s[0] == '[':
+			end := strings.Index(s, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("query %q: unterminated index", q)
+			}
+			i, err := strconv.Atoi(s[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("query %q: invalid index: %s", q, s[1:end])
+			}
+			query = append(query, queryStep{index: i})
+			s = s[end+1:]
+		case s[0] == '.':
+			end := strings.IndexAny(s[1:], ".[") + 1
+			if end == 0 {
+				end = len(s)
+			}
+			// A dot before a bracket only separates them, as in .items.[0].
+			if end > 1 {
+				name := s[1:end]
+				query = append(query, queryStep{field: &name})
+			}
+			s = s[end:]
+		default:
+			return nil, fmt.Errorf("query %q: unexpected %q", q, s)
+		}
+	}
+	return query, nil
+}
+
+// Select returns the values q selects from v. Protobuf messages are
+// selected from as they are encoded in json, and objects and numbers are
+// returned as they are decoded by encoding/json, with json.Number for
+// numbers.
+func (q Query) Select(v interface{}) ([]interface{}, error) {
+	opts := ProtoJSONOptions
+	opts.EmitUnpopulated = true
+	b, err := marshalJSON(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var root interface{}
+	if err := d.Decode(&root); err != nil {
+		return nil, err
+	}
+	values := []interface{}{root}
+	for _, step := range q {
+		var next []interface{}
+		for _, v := range values {
+			selected, err := step.selectFrom(v)
+			if err != nil {
+				return nil, fmt.Errorf("query: %v", err)
+			}
+			next = append(next, selected...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+// selectFrom returns the values step selects from v. Nothing is selected
+// from null, e.g. a message field that is not set, but null.
+func (step queryStep) selectFrom(v interface{}) ([]interface{}, error) {
+	if v == nil {
+		if step.all {
+			return nil, nil
+		}
+		return []interface{}{nil}, nil
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		switch {
+		case step.all:
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			values := make([]interface{}, len(keys))
+			for i, k := range keys {
+				values[i] = v[k]
+			}
+			return values, nil
+		case step.field != nil:
+			e, ok := v[*step.field]
+			if !ok {
+				return nil, fmt.Errorf("no field %q", *step.field)
+			}
+			return []interface{}{e}, nil
+		}
+	case []interface{}:
+		switch {
+		case step.all:
+			return v, nil
+		case step.field == nil:
+			i := step.index
+			if i < 0 {
+				i += len(v)
+			}
+			if i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("index %d out of range of %d elements", step.index, len(v))
+			}
+			return []interface{}{v[i]}, nil
+		}
+	}
+	switch {
+	case step.all:
+		return nil, fmt.Errorf("cannot select the elements of %v", v)
+	case step.field != nil:
+		return nil, fmt.Errorf("cannot select field %q of %v", *step.field, v)
+	}
+	return nil, fmt.Errorf("cannot select element %d of %v", step.index, v)
+}
+
+// QueryEncoderMaker returns an EncoderMaker for encoders that write the
+// values q selects from each value: strings as they are, followed by a
+// newline, so scripts get them bare, and other values with the encoders
+// of em.
+func QueryEncoderMaker(q Query, em EncoderMaker) EncoderMaker {
+	return EncoderMakerFunc(func(w io.Writer) Encoder {
+		return &queryEncoder{w, q, em.NewEncoder(w)}
+	})
+}
+
+type queryEncoder struct {
+	w io.Writer
+	q Query
+	e Encoder
+}
+
+func (qe *queryEncoder) Encode(v interface{}) error {
+	values, err := qe.q.Select(v)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			if _, err := fmt.Fprintln(qe.w, s); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := qe.e.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package iocodec
+
+// Register adds the format name to DefaultEncoders, DefaultDecoders and
+// StrictDecoders, with the encoders of em and the decoders of dm. Either
+// may be nil, for formats that are only written or only read, and leaves
+// the encoders or decoders of name as they are. The commands generated by
+// protoc-gen-cobra resolve formats with LookupEncoder and LookupDecoder,
+// so registered formats can be used as response and request formats, and
+// as the extensions of request files, without generating the commands
+// again, e.g.
+//
+//	func init() {
+//		iocodec.Register("msgpack", msgpackEncoderMaker, msgpackDecoderMaker)
+//	}
+//
+// Registering the encoders of a format again replaces them, and drops its
+// variants in ColorEncoders and ProtoJSONEncoders, e.g. of prettyjson.
+// Register is not safe for concurrent use, and is meant to be called
+// before any command runs, e.g. from init functions.
+func Register(name string, em EncoderMaker, dm DecoderMaker) {
+	if em != nil {
+		DefaultEncoders[name] = em
+		delete(ColorEncoders, name)
+		delete(ProtoJSONEncoders, name)
+	}
+	if dm != nil {
+		DefaultDecoders[name] = dm
+		StrictDecoders[name] = dm
+	}
+}
+
+// LookupEncoder returns the EncoderMaker of the format name in
+// DefaultEncoders, which may also be an alias of one.
+func LookupEncoder(name string) (EncoderMaker, bool) {
+	return DefaultEncoders.Lookup(name)
+}
+
+// LookupDecoder returns the DecoderMaker of the format name in
+// DefaultDecoders, or in StrictDecoders if strict is true, which may also
+// be an alias of one.
+func LookupDecoder(name string, strict bool) (DecoderMaker, bool) {
+	if strict {
+		return StrictDecoders.Lookup(name)
+	}
+	return DefaultDecoders.Lookup(name)
+}
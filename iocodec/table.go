@@ -0,0 +1,199 @@
+package iocodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// tableEncoder writes protobuf messages as the rows of a table of aligned
+// columns, one per field, under a header of the uppercased field names, as
+// kubectl does. The elements of the only repeated message field of a
+// message whose other fields are pagination metadata, e.g. the items of a
+// list response, are the rows instead, and the metadata is left out. Values are written as in the JSON mapping
+// of proto3, with ProtoJSONOptions, and messages and repeated fields as
+// compact JSON; well-known types, whose JSON forms have no fields, have a
+// single column. The messages of a server stream add rows to the table,
+// and columns widen as longer values come in.
+type tableEncoder struct {
+	w      io.Writer
+	widths []int
+}
+
+func (te *tableEncoder) Encode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("cannot encode %T as a table", v)
+	}
+	rows, md := tableRows(proto.MessageReflect(m))
+	header := tableHeader(md)
+	if len(header) == 0 {
+		return nil
+	}
+	if te.widths != nil && len(te.widths) != len(header) {
+		return fmt.Errorf("cannot add %s to a table of other columns", md.FullName())
+	}
+	var cells [][]string
+	if te.widths == nil {
+		te.widths = make([]int, len(header))
+		cells = append(cells, header)
+	}
+	for _, r := range rows {
+		row, err := tableRow(r)
+		if err != nil {
+			return err
+		}
+		cells = append(cells, row)
+	}
+	for _, row := range cells {
+		for i, c := range row {
+			if n := utf8.RuneCountInString(c); n > te.widths[i] {
+				te.widths[i] = n
+			}
+		}
+	}
+	var b bytes.Buffer
+	for _, row := range cells {
+		var line bytes.Buffer
+		for i, c := range row {
+			line.WriteString(c)
+			if i < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", te.widths[i]-utf8.RuneCountInString(c)+3))
+			}
+		}
+		b.Write(bytes.TrimRight(line.Bytes(), " "))
+		b.WriteByte('\n')
+	}
+	_, err := te.w.Write(b.Bytes())
+	return err
+}
+
+// tableRows returns the messages that are the rows of the table of m, and
+// their type: the elements of its rowsField, if it has one, or else m.
+func tableRows(m protoreflect.Message) ([]protoreflect.Message, protoreflect.MessageDescriptor) {
+	fd := rowsField(m.Descriptor())
+	if fd == nil {
+		return []protoreflect.Message{m}, m.Descriptor()
+	}
+	list := m.Get(fd).List()
+	rows := make([]protoreflect.Message, list.Len())
+	for i := range rows {
+		rows[i] = list.Get(i).Message()
+	}
+	return rows, fd.Message()
+}
+
+// paginationFields are the names of the fields of list responses that
+// describe the page rather than its items, by the conventions of AIP-158
+// and AIP-217.
+var paginationFields = map[protoreflect.Name]bool{
+	"next_page_token":     true,
+	"prev_page_token":     true,
+	"previous_page_token": true,
+	"total_size":          true,
+	"total_count":         true,
+	"unreachable":         true,
+}
+
+// rowsField returns the only repeated message field of md, whose elements
+// are the rows of its table, or nil if it has none, or md has fields other
+// than it and paginationFields, whose values would be lost. Fields of
+// well-known types, e.g. the details of a google.rpc.Status, are not rows.
+func rowsField(md protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	var rf protoreflect.FieldDescriptor
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if paginationFields[fd.Name()] {
+			continue
+		}
+		if !fd.IsList() || fd.Message() == nil || wellKnown(fd.Message()) || rf != nil {
+			return nil
+		}
+		rf = fd
+	}
+	return rf
+}
+
+// tableHeader returns the names of the columns of the table of messages
+// of type md: the uppercased names of its fields, or of md itself if it is
+// a well-known type.
+func tableHeader(md protoreflect.MessageDescriptor) []string {
+	if md.Fields().Len() == 0 {
+		return nil
+	}
+	if wellKnown(md) {
+		return []string{strings.ToUpper(string(md.Name()))}
+	}
+	fields := md.Fields()
+	header := make([]string, fields.Len())
+	for i := range header {
+		header[i] = strings.ToUpper(string(fields.Get(i).Name()))
+	}
+	return header
+}
+
+// tableRow returns the cells of the fields of m, in declaration order, or
+// the single cell of m if it is a well-known type. Fields that are not set
+// have empty cells.
+func tableRow(m protoreflect.Message) ([]string, error) {
+	b, err := ProtoJSONOptions.Marshal(m.Interface())
+	if err != nil {
+		return nil, err
+	}
+	if wellKnown(m.Descriptor()) {
+		c, err := tableCell(b)
+		return []string{c}, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, err
+	}
+	fields := m.Descriptor().Fields()
+	row := make([]string, fields.Len())
+	for i := range row {
+		raw, ok := values[jsonName(fields.Get(i))]
+		if !ok {
+			continue
+		}
+		if row[i], err = tableCell(raw); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// jsonName returns the name of fd in the json of ProtoJSONOptions.
+func jsonName(fd protoreflect.FieldDescriptor) string {
+	if ProtoJSONOptions.UseProtoNames {
+		return string(fd.Name())
+	}
+	return fd.JSONName()
+}
+
+// wellKnown reports whether md is a well-known type, other than those of
+// descriptor.proto, which have JSON forms of their own.
+func wellKnown(md protoreflect.MessageDescriptor) bool {
+	f := md.ParentFile()
+	return f.Package() == "google.protobuf" && f.Path() != "google/protobuf/descriptor.proto"
+}
+
+// tableCell returns the cell of the JSON value raw: the string, on one
+// line, if it is one, or else the compact JSON.
+func tableCell(raw []byte) (string, error) {
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		var c bytes.Buffer
+		if err := json.Compact(&c, raw); err != nil {
+			return "", err
+		}
+		s = c.String()
+	}
+	return strings.NewReplacer("\n", " ", "\t", " ").Replace(s), nil
+}
@@ -0,0 +1,77 @@
+package iocodec
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testListFile returns the file of an Item message, and of messages of
+// repeated items: a ListItemsResponse with pagination metadata, and a
+// Batch with a field of its own.
+func testListFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	const (
+		str = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		i32 = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	)
+	items := func() *descriptorpb.FieldDescriptorProto {
+		f := testField("items", 1, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".iocodec.list.Item")
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return f
+	}
+	return testFile(t, "iocodec.list", nil,
+		&descriptorpb.DescriptorProto{Name: proto.String("Item"), Field: []*descriptorpb.FieldDescriptorProto{
+			testField("name", 1, str, ""),
+			testField("size", 2, i32, ""),
+		}},
+		&descriptorpb.DescriptorProto{Name: proto.String("ListItemsResponse"), Field: []*descriptorpb.FieldDescriptorProto{
+			items(),
+			testField("next_page_token", 2, str, ""),
+			testField("total_size", 3, i32, ""),
+		}},
+		&descriptorpb.DescriptorProto{Name: proto.String("Batch"), Field: []*descriptorpb.FieldDescriptorProto{
+			items(),
+			testField("owner", 2, str, ""),
+		}},
+	)
+}
+
+func TestTableRows(t *testing.T) {
+	file := testListFile(t)
+	for _, tc := range []struct {
+		message, json, table, csv string
+	}{
+		{
+			message: "ListItemsResponse",
+			json:    `{"items": [{"name": "a", "size": 1}, {"name": "b", "size": 2}], "next_page_token": "p2", "total_size": 9}`,
+			table:   "NAME   SIZE\na      1\nb      2\n",
+			csv:     "name,size\na,1\nb,2\n",
+		},
+		{
+			message: "Batch",
+			json:    `{"items": [{"name": "a", "size": 1}], "owner": "me"}`,
+			table:   "ITEMS                     OWNER\n[{\"name\":\"a\",\"size\":1}]   me\n",
+			csv:     "items,owner\n\"[{\"\"name\"\":\"\"a\"\",\"\"size\"\":1}]\",me\n",
+		},
+	} {
+		m := dynamicpb.NewMessage(file.Messages().ByName(protoreflect.Name(tc.message)))
+		if err := protojson.Unmarshal([]byte(tc.json), m); err != nil {
+			t.Fatal(err)
+		}
+		for format, want := range map[string]string{"table": tc.table, "csv": tc.csv} {
+			var b bytes.Buffer
+			if err := DefaultEncoders[format].NewEncoder(&b).Encode(m); err != nil {
+				t.Fatalf("%s %s: %v", tc.message, format, err)
+			}
+			if b.String() != want {
+				t.Errorf("%s %s:\n%s\nwant:\n%s", tc.message, format, b.String(), want)
+			}
+		}
+	}
+}
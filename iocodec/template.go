@@ -0,0 +1,59 @@
+package iocodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// TemplateFuncs contains the functions available to response templates,
+// in addition to the text/template builtins.
+var TemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}
+
+// ParseTemplateFile parses the response template in the named file, with
+// TemplateFuncs.
+func ParseTemplateFile(name string) (*template.Template, error) {
+	return template.New(filepath.Base(name)).Funcs(TemplateFuncs).ParseFiles(name)
+}
+
+// ParseTemplate parses the response template text, with TemplateFuncs.
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("output-template").Funcs(TemplateFuncs).Parse(text)
+}
+
+// TemplateEncoderMaker returns an EncoderMaker for encoders that render
+// each value with t, followed by a newline if the output does not end
+// with one.
+func TemplateEncoderMaker(t *template.Template) EncoderMaker {
+	return EncoderMakerFunc(func(w io.Writer) Encoder {
+		return &templateEncoder{w, t}
+	})
+}
+
+type templateEncoder struct {
+	w io.Writer
+	t *template.Template
+}
+
+func (te *templateEncoder) Encode(v interface{}) error {
+	var b bytes.Buffer
+	if err := te.t.Execute(&b, v); err != nil {
+		return err
+	}
+	if b.Len() > 0 && b.Bytes()[b.Len()-1] != '\n' {
+		b.WriteByte('\n')
+	}
+	_, err := te.w.Write(b.Bytes())
+	return err
+}
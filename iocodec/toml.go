@@ -0,0 +1,113 @@
+package iocodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pelletier/go-toml/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// tomlEncoder writes protobuf messages as TOML documents of their JSON
+// mapping of proto3, with the options opts, so that they can be read back
+// by tomlDecoder. Keys are sorted, and null values, which TOML lacks, are
+// left out. If array is set, each message is instead a table of the array
+// of tables of that name, e.g. [[response]], so that the messages of a
+// stream, which TOML has no documents for, make up a single valid file.
+// Other values are encoded with go-toml.
+type tomlEncoder struct {
+	w     io.Writer
+	opts  *protojson.MarshalOptions
+	array string
+}
+
+func (te *tomlEncoder) Encode(v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return toml.NewEncoder(te.w).Encode(v)
+	}
+	b, err := te.opts.Marshal(proto.MessageV2(m))
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var doc interface{}
+	if err := d.Decode(&doc); err != nil {
+		return err
+	}
+	if _, ok := doc.(map[string]interface{}); !ok {
+		return fmt.Errorf("cannot encode %s in toml, which only has tables at the top", m)
+	}
+	doc = dropNulls(doc)
+	if te.array != "" {
+		doc = map[string]interface{}{te.array: []interface{}{doc}}
+	}
+	return toml.NewEncoder(te.w).SetMarshalJsonNumbers(true).Encode(doc)
+}
+
+// dropNulls returns the decoded json value v without the null values of
+// its objects and lists.
+func dropNulls(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if e == nil {
+				delete(v, k)
+				continue
+			}
+			v[k] = dropNulls(e)
+		}
+	case []interface{}:
+		o := v[:0]
+		for _, e := range v {
+			if e != nil {
+				o = append(o, dropNulls(e))
+			}
+		}
+		return o
+	}
+	return v
+}
+
+// tomlDecoder reads a single protobuf message from all of its input, a
+// TOML document, which is decoded with protojson as protoYAMLDecoder
+// decodes YAML documents, so fields may have their proto or json_name
+// names, and well-known types their JSON forms, e.g. offset date-times for
+// timestamps. Decoding again returns io.EOF. Other values are decoded with
+// go-toml.
+type tomlDecoder struct {
+	r      io.Reader
+	strict bool
+	done   bool
+}
+
+func (td *tomlDecoder) Decode(v interface{}) error {
+	if td.done {
+		return io.EOF
+	}
+	td.done = true
+	m, ok := v.(proto.Message)
+	if !ok {
+		return toml.NewDecoder(td.r).Decode(v)
+	}
+	b, err := ioutil.ReadAll(td.r)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	mr := proto.MessageReflect(m)
+	jb, err := json.Marshal(yamlToJSON(doc, mr.Descriptor()))
+	if err != nil {
+		return err
+	}
+	u := protojson.UnmarshalOptions{DiscardUnknown: !td.strict}
+	return u.Unmarshal(jb, mr.Interface())
+}
@@ -0,0 +1,57 @@
+package iocodec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTOMLEncoderStream(t *testing.T) {
+	mt := testRecordType(t)
+	var b bytes.Buffer
+	e := DefaultEncoders["toml"].NewEncoder(&b)
+	for _, s := range []string{
+		`{"id": "1", "labels": {"env": "prod", "limits": {"cpu": 2}}}`,
+		`{"id": "2", "kind": "CHECK"}`,
+		`{"id": "3", "labels": {"env": "dev"}}`,
+	} {
+		if err := e.Encode(testRecord(t, mt, s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var doc struct {
+		Response []map[string]interface{}
+	}
+	if err := toml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("stream is not valid toml: %v\n%s", err, b.String())
+	}
+	if len(doc.Response) != 3 {
+		t.Fatalf("stream has %d responses, want 3:\n%s", len(doc.Response), b.String())
+	}
+	for i, want := range []string{"1", "2", "3"} {
+		if id := doc.Response[i]["id"]; id != want {
+			t.Errorf("response %d has id %v, want %s", i, id, want)
+		}
+	}
+	if labels, _ := doc.Response[2]["labels"].(map[string]interface{}); labels["env"] != "dev" {
+		t.Errorf("response 2 has labels %v, want env dev", doc.Response[2]["labels"])
+	}
+}
+
+func TestTOMLSampleRoundTrip(t *testing.T) {
+	mt := testRecordType(t)
+	m := testRecord(t, mt, `{"id": "9007199254740993", "data": "Ynl0ZXM=", "kind": "CHECK", "at": "2006-01-02T15:04:05Z", "labels": {"env": "prod"}}`)
+	var b bytes.Buffer
+	if err := ProtoJSONEncoders["toml"].NewEncoder(&b).Encode(m); err != nil {
+		t.Fatal(err)
+	}
+	got := mt.New().Interface()
+	if err := DefaultDecoders["toml"].NewDecoder(&b).Decode(got); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, m) {
+		t.Errorf("decoded %v, want %v", got, m)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"os"
 
 	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 
 	"github.com/fiorix/protoc-gen-cobra/generator"
 )
@@ -60,9 +61,14 @@ func main() {
 	g.WrapTypes()
 
 	g.SetPackageNames()
+	g.BuildTypeNameMap()
 
 	g.GenerateAllFiles()
 
+	// Commands set proto3 optional fields through protoreflect, which
+	// tracks their presence.
+	g.Response.SupportedFeatures = proto.Uint64(uint64(plugin.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
+
 	// Send back the results.
 	data, err = proto.Marshal(g.Response)
 	if err != nil {
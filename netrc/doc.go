@@ -0,0 +1,3 @@
+// Package netrc reads credentials from netrc files, as used by curl,
+// git, and ftp.
+package netrc
@@ -0,0 +1,97 @@
+package netrc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultFile returns the path of the user's netrc file, ~/.netrc, or the
+// value of the NETRC environment variable if set.
+func DefaultFile() string {
+	if f := os.Getenv("NETRC"); f != "" {
+		return f
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// Machine holds the credentials of a machine entry of a netrc file.
+type Machine struct {
+	Name     string
+	Login    string
+	Password string
+}
+
+// Lookup returns the entry of the named machine in the netrc file, or the
+// default entry if there is no such machine. It returns nil if neither is
+// found.
+func Lookup(file, name string) (*Machine, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	tokens := tokenize(string(b))
+	var machines []*Machine
+	var m, def *Machine
+	for i := 0; i < len(tokens); i++ {
+		next := func() string {
+			if i+1 < len(tokens) {
+				i++
+				return tokens[i]
+			}
+			return ""
+		}
+		switch tokens[i] {
+		case "machine":
+			m = &Machine{Name: next()}
+			machines = append(machines, m)
+		case "default":
+			m = &Machine{}
+			def = m
+		case "login":
+			if m != nil {
+				m.Login = next()
+			}
+		case "password":
+			if m != nil {
+				m.Password = next()
+			}
+		case "account":
+			next()
+		}
+	}
+	for _, m := range machines {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return def, nil
+}
+
+// tokenize splits the contents of a netrc file into tokens, skipping
+// comment lines, which start with #, and macro definitions, which run
+// from a macdef token to the next blank line.
+func tokenize(s string) []string {
+	var tokens []string
+	lines := strings.Split(s, "\n")
+	for i := 0; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "#") {
+			continue
+		}
+		for _, f := range strings.Fields(lines[i]) {
+			if f == "macdef" {
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+				break
+			}
+			tokens = append(tokens, f)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,465 @@
+// Custom options understood by protoc-gen-cobra.
+//
+// Import this file to control how services, methods, and fields are
+// exposed by the generated commands, e.g.:
+//
+//	import "github.com/fiorix/protoc-gen-cobra/options/cobra.proto";
+//
+//	service Bank {
+//		rpc Deposit(DepositRequest) returns (DepositReply) {
+//			option (protoc_gen_cobra.method) = {aliases: ["dep"]};
+//		}
+//	}
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: github.com/fiorix/protoc-gen-cobra/options/cobra.proto
+
+package options
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Metadata is a key/value pair sent with every call.
+type Metadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *string                `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value         *string                `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Metadata) GetKey() string {
+	if x != nil && x.Key != nil {
+		return *x.Key
+	}
+	return ""
+}
+
+func (x *Metadata) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+type ServiceOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Aliases of the service command.
+	Aliases []string `protobuf:"bytes,1,rep,name=aliases" json:"aliases,omitempty"`
+	// Metadata sent with every call of the service.
+	Metadata      []*Metadata `protobuf:"bytes,2,rep,name=metadata" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceOptions) Reset() {
+	*x = ServiceOptions{}
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceOptions) ProtoMessage() {}
+
+func (x *ServiceOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceOptions.ProtoReflect.Descriptor instead.
+func (*ServiceOptions) Descriptor() ([]byte, []int) {
+	return file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServiceOptions) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *ServiceOptions) GetMetadata() []*Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type MethodOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Aliases of the method command.
+	Aliases []string `protobuf:"bytes,1,rep,name=aliases" json:"aliases,omitempty"`
+	// Metadata sent with every call of the method, after the
+	// metadata of the service.
+	Metadata []*Metadata `protobuf:"bytes,2,rep,name=metadata" json:"metadata,omitempty"`
+	// Default deadline of calls of the method, e.g. "30s", used unless
+	// the deadline is set by flag or environment variable.
+	Deadline *string `protobuf:"bytes,3,opt,name=deadline" json:"deadline,omitempty"`
+	// Request fields set by the positional arguments of the method
+	// command, in order, by their dotted field paths, e.g. "key" for
+	// "cache get KEY". The last may be a repeated field, set by the
+	// remaining arguments.
+	Args          []string `protobuf:"bytes,4,rep,name=args" json:"args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodOptions) Reset() {
+	*x = MethodOptions{}
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodOptions) ProtoMessage() {}
+
+func (x *MethodOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodOptions.ProtoReflect.Descriptor instead.
+func (*MethodOptions) Descriptor() ([]byte, []int) {
+	return file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MethodOptions) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *MethodOptions) GetMetadata() []*Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *MethodOptions) GetDeadline() string {
+	if x != nil && x.Deadline != nil {
+		return *x.Deadline
+	}
+	return ""
+}
+
+func (x *MethodOptions) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type FlagOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the flag of the field, instead of the field name with
+	// dashes for underscores. In nested messages, it replaces the name
+	// of the field in the dotted flag names.
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// One letter shorthand of the flag of a scalar field, e.g. "a" for
+	// -a.
+	Shorthand *string `protobuf:"bytes,2,opt,name=shorthand" json:"shorthand,omitempty"`
+	// Usage of the flag of a scalar field, instead of "request field"
+	// and the field name.
+	Usage *string `protobuf:"bytes,3,opt,name=usage" json:"usage,omitempty"`
+	// Hide the flag of a scalar field from the usage of the command.
+	Hidden *bool `protobuf:"varint,4,opt,name=hidden" json:"hidden,omitempty"`
+	// Default value of the flag of a scalar field, in the form of its
+	// arguments, e.g. "10" or "a,b" for a repeated field. It sets the
+	// field unless the request sets it, and takes precedence over the
+	// default of a proto2 field.
+	Default *string `protobuf:"bytes,5,opt,name=default" json:"default,omitempty"`
+	// Skip the field: it has no flag, nor do the fields of a message
+	// field, e.g. to stop the expansion of deep messages.
+	Skip *bool `protobuf:"varint,6,opt,name=skip" json:"skip,omitempty"`
+	// Environment variable whose value, if set, is the default of the
+	// flag of a scalar field, e.g. "ACCOUNT_ID". It takes precedence
+	// over the default option.
+	Env           *string `protobuf:"bytes,7,opt,name=env" json:"env,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlagOptions) Reset() {
+	*x = FlagOptions{}
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlagOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagOptions) ProtoMessage() {}
+
+func (x *FlagOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlagOptions.ProtoReflect.Descriptor instead.
+func (*FlagOptions) Descriptor() ([]byte, []int) {
+	return file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FlagOptions) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *FlagOptions) GetShorthand() string {
+	if x != nil && x.Shorthand != nil {
+		return *x.Shorthand
+	}
+	return ""
+}
+
+func (x *FlagOptions) GetUsage() string {
+	if x != nil && x.Usage != nil {
+		return *x.Usage
+	}
+	return ""
+}
+
+func (x *FlagOptions) GetHidden() bool {
+	if x != nil && x.Hidden != nil {
+		return *x.Hidden
+	}
+	return false
+}
+
+func (x *FlagOptions) GetDefault() string {
+	if x != nil && x.Default != nil {
+		return *x.Default
+	}
+	return ""
+}
+
+func (x *FlagOptions) GetSkip() bool {
+	if x != nil && x.Skip != nil {
+		return *x.Skip
+	}
+	return false
+}
+
+func (x *FlagOptions) GetEnv() string {
+	if x != nil && x.Env != nil {
+		return *x.Env
+	}
+	return ""
+}
+
+var file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.ServiceOptions)(nil),
+		ExtensionType: (*ServiceOptions)(nil),
+		Field:         51100,
+		Name:          "protoc_gen_cobra.service",
+		Tag:           "bytes,51100,opt,name=service",
+		Filename:      "github.com/fiorix/protoc-gen-cobra/options/cobra.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*MethodOptions)(nil),
+		Field:         51100,
+		Name:          "protoc_gen_cobra.method",
+		Tag:           "bytes,51100,opt,name=method",
+		Filename:      "github.com/fiorix/protoc-gen-cobra/options/cobra.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FlagOptions)(nil),
+		Field:         51100,
+		Name:          "protoc_gen_cobra.flag",
+		Tag:           "bytes,51100,opt,name=flag",
+		Filename:      "github.com/fiorix/protoc-gen-cobra/options/cobra.proto",
+	},
+}
+
+// Extension fields to descriptorpb.ServiceOptions.
+var (
+	// optional protoc_gen_cobra.ServiceOptions service = 51100;
+	E_Service = &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_extTypes[0]
+)
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional protoc_gen_cobra.MethodOptions method = 51100;
+	E_Method = &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_extTypes[1]
+)
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional protoc_gen_cobra.FlagOptions flag = 51100;
+	E_Flag = &file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_extTypes[2]
+)
+
+var File_github_com_fiorix_protoc_gen_cobra_options_cobra_proto protoreflect.FileDescriptor
+
+const file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDesc = "" +
+	"\n" +
+	"6github.com/fiorix/protoc-gen-cobra/options/cobra.proto\x12\x10protoc_gen_cobra\x1a google/protobuf/descriptor.proto\"2\n" +
+	"\bMetadata\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"b\n" +
+	"\x0eServiceOptions\x12\x18\n" +
+	"\aaliases\x18\x01 \x03(\tR\aaliases\x126\n" +
+	"\bmetadata\x18\x02 \x03(\v2\x1a.protoc_gen_cobra.MetadataR\bmetadata\"\x91\x01\n" +
+	"\rMethodOptions\x12\x18\n" +
+	"\aaliases\x18\x01 \x03(\tR\aaliases\x126\n" +
+	"\bmetadata\x18\x02 \x03(\v2\x1a.protoc_gen_cobra.MetadataR\bmetadata\x12\x1a\n" +
+	"\bdeadline\x18\x03 \x01(\tR\bdeadline\x12\x12\n" +
+	"\x04args\x18\x04 \x03(\tR\x04args\"\xad\x01\n" +
+	"\vFlagOptions\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tshorthand\x18\x02 \x01(\tR\tshorthand\x12\x14\n" +
+	"\x05usage\x18\x03 \x01(\tR\x05usage\x12\x16\n" +
+	"\x06hidden\x18\x04 \x01(\bR\x06hidden\x12\x18\n" +
+	"\adefault\x18\x05 \x01(\tR\adefault\x12\x12\n" +
+	"\x04skip\x18\x06 \x01(\bR\x04skip\x12\x10\n" +
+	"\x03env\x18\a \x01(\tR\x03env:]\n" +
+	"\aservice\x12\x1f.google.protobuf.ServiceOptions\x18\x9c\x8f\x03 \x01(\v2 .protoc_gen_cobra.ServiceOptionsR\aservice:Y\n" +
+	"\x06method\x12\x1e.google.protobuf.MethodOptions\x18\x9c\x8f\x03 \x01(\v2\x1f.protoc_gen_cobra.MethodOptionsR\x06method:R\n" +
+	"\x04flag\x12\x1d.google.protobuf.FieldOptions\x18\x9c\x8f\x03 \x01(\v2\x1d.protoc_gen_cobra.FlagOptionsR\x04flagB,Z*github.com/fiorix/protoc-gen-cobra/options"
+
+var (
+	file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescOnce sync.Once
+	file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescData []byte
+)
+
+func file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescGZIP() []byte {
+	file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescOnce.Do(func() {
+		file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDesc), len(file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDesc)))
+	})
+	return file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDescData
+}
+
+var file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_goTypes = []any{
+	(*Metadata)(nil),                    // 0: protoc_gen_cobra.Metadata
+	(*ServiceOptions)(nil),              // 1: protoc_gen_cobra.ServiceOptions
+	(*MethodOptions)(nil),               // 2: protoc_gen_cobra.MethodOptions
+	(*FlagOptions)(nil),                 // 3: protoc_gen_cobra.FlagOptions
+	(*descriptorpb.ServiceOptions)(nil), // 4: google.protobuf.ServiceOptions
+	(*descriptorpb.MethodOptions)(nil),  // 5: google.protobuf.MethodOptions
+	(*descriptorpb.FieldOptions)(nil),   // 6: google.protobuf.FieldOptions
+}
+var file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_depIdxs = []int32{
+	0, // 0: protoc_gen_cobra.ServiceOptions.metadata:type_name -> protoc_gen_cobra.Metadata
+	0, // 1: protoc_gen_cobra.MethodOptions.metadata:type_name -> protoc_gen_cobra.Metadata
+	4, // 2: protoc_gen_cobra.service:extendee -> google.protobuf.ServiceOptions
+	5, // 3: protoc_gen_cobra.method:extendee -> google.protobuf.MethodOptions
+	6, // 4: protoc_gen_cobra.flag:extendee -> google.protobuf.FieldOptions
+	1, // 5: protoc_gen_cobra.service:type_name -> protoc_gen_cobra.ServiceOptions
+	2, // 6: protoc_gen_cobra.method:type_name -> protoc_gen_cobra.MethodOptions
+	3, // 7: protoc_gen_cobra.flag:type_name -> protoc_gen_cobra.FlagOptions
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	5, // [5:8] is the sub-list for extension type_name
+	2, // [2:5] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_init() }
+func file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_init() {
+	if File_github_com_fiorix_protoc_gen_cobra_options_cobra_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDesc), len(file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 3,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_goTypes,
+		DependencyIndexes: file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_depIdxs,
+		MessageInfos:      file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_msgTypes,
+		ExtensionInfos:    file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_extTypes,
+	}.Build()
+	File_github_com_fiorix_protoc_gen_cobra_options_cobra_proto = out.File
+	file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_goTypes = nil
+	file_github_com_fiorix_protoc_gen_cobra_options_cobra_proto_depIdxs = nil
+}
@@ -0,0 +1,129 @@
+// Package options declares the custom options of cobra.proto, which
+// control how services, methods, and fields are exposed by the commands
+// generated by protoc-gen-cobra. Their types are generated by
+// protoc-gen-go in cobra.pb.go.
+package options
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The options of other plugins the generator reads. They are read from
+// the encoded options rather than registered as extensions here, which
+// would conflict with the packages that declare them, such as the
+// annotations of genproto and the validate package of protoc-gen-validate.
+const (
+	// fieldBehaviorField is the number of the google.api.field_behavior
+	// option of google.protobuf.FieldOptions, declared in
+	// google/api/field_behavior.proto of the Google APIs. Its values are
+	// those of the google.api.FieldBehavior enum.
+	fieldBehaviorField = 1052
+	// fieldBehaviorRequired is the REQUIRED value of google.api.FieldBehavior.
+	fieldBehaviorRequired = 2
+	// validateField is the number of the validate.rules option of
+	// google.protobuf.FieldOptions, and of the validate.required option of
+	// google.protobuf.OneofOptions, declared in validate/validate.proto of
+	// protoc-gen-validate.
+	validateField = 1071
+)
+
+// Service returns the (protoc_gen_cobra.service) option of s, or an empty value if
+// it is not set.
+func Service(s *descriptor.ServiceDescriptorProto) *ServiceOptions {
+	if v, err := proto.GetExtension(s.GetOptions(), E_Service); err == nil {
+		return v.(*ServiceOptions)
+	}
+	return &ServiceOptions{}
+}
+
+// Method returns the (protoc_gen_cobra.method) option of m, or an empty value if
+// it is not set.
+func Method(m *descriptor.MethodDescriptorProto) *MethodOptions {
+	if v, err := proto.GetExtension(m.GetOptions(), E_Method); err == nil {
+		return v.(*MethodOptions)
+	}
+	return &MethodOptions{}
+}
+
+// Flag returns the (protoc_gen_cobra.flag) option of f, or an empty value if it is
+// not set.
+func Flag(f *descriptor.FieldDescriptorProto) *FlagOptions {
+	if v, err := proto.GetExtension(f.GetOptions(), E_Flag); err == nil {
+		return v.(*FlagOptions)
+	}
+	return &FlagOptions{}
+}
+
+// Required reports whether the google.api.field_behavior option of f
+// includes REQUIRED.
+func Required(f *descriptor.FieldDescriptorProto) bool {
+	required := false
+	rawOption(f.GetOptions(), fieldBehaviorField, func(typ protowire.Type, b []byte) {
+		switch typ {
+		case protowire.VarintType:
+			v, _ := protowire.ConsumeVarint(b)
+			required = required || v == fieldBehaviorRequired
+		case protowire.BytesType:
+			b, _ = protowire.ConsumeBytes(b)
+			for len(b) > 0 {
+				v, n := protowire.ConsumeVarint(b)
+				if n < 0 {
+					return
+				}
+				required = required || v == fieldBehaviorRequired
+				b = b[n:]
+			}
+		}
+	})
+	return required
+}
+
+// ValidateRules reports whether f has protoc-gen-validate rules, set by
+// its validate.rules option.
+func ValidateRules(f *descriptor.FieldDescriptorProto) bool {
+	rules := false
+	rawOption(f.GetOptions(), validateField, func(typ protowire.Type, b []byte) {
+		rules = rules || typ == protowire.BytesType
+	})
+	return rules
+}
+
+// ValidateRequired reports whether the validate.required option of o is
+// set, so protoc-gen-validate requires one of its fields to be set.
+func ValidateRequired(o *descriptor.OneofDescriptorProto) bool {
+	required := false
+	rawOption(o.GetOptions(), validateField, func(typ protowire.Type, b []byte) {
+		if typ == protowire.VarintType {
+			v, _ := protowire.ConsumeVarint(b)
+			required = v != 0
+		}
+	})
+	return required
+}
+
+// rawOption calls fn with the wire type and encoded value of each
+// occurrence of the field num in the options m, in order, whether the
+// option is an extension registered in the generator or an unknown field.
+func rawOption(m proto.Message, num protowire.Number, fn func(typ protowire.Type, b []byte)) {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return
+	}
+	for len(b) > 0 {
+		n, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return
+		}
+		b = b[l:]
+		l = protowire.ConsumeFieldValue(n, typ, b)
+		if l < 0 {
+			return
+		}
+		if n == num {
+			fn(typ, b[:l])
+		}
+		b = b[l:]
+	}
+}
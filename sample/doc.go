@@ -0,0 +1,2 @@
+// Package sample populates messages with illustrative values.
+package sample
@@ -0,0 +1,155 @@
+package sample
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// sampleTime is the placeholder value of timestamps.
+var sampleTime = time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// Populate sets the fields of the message pointed to by v to
+// type-appropriate placeholder values, so that the encoded message can be
+// used as a template for request files and shows the shape of the whole
+// request. Nested messages are populated too, repeated fields have one
+// element, and maps one entry. Of the fields of a oneof, only the first is
+// set. Enum fields have their first non-zero value, and well-known types
+// have values of their json forms, e.g. 2006-01-02T15:04:05Z for
+// timestamps. The common google.type messages have the values of their
+// flag forms, e.g. 12.34 USD for Money. Message fields of a type that is
+// already being populated, which would expand forever, and Any fields,
+// which need a registered type, are not set. Nor are validate_only bool
+// fields, so requests made from samples are executed by servers that
+// follow AIP-163.
+func Populate(v interface{}) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return
+	}
+	populate(proto.MessageReflect(m), map[protoreflect.FullName]bool{})
+}
+
+func populate(m protoreflect.Message, parents map[protoreflect.FullName]bool) {
+	md := m.Descriptor()
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		m.Set(md.Fields().ByName("seconds"), protoreflect.ValueOfInt64(sampleTime.Unix()))
+		return
+	case "google.protobuf.Duration":
+		m.Set(md.Fields().ByName("seconds"), protoreflect.ValueOfInt64(1))
+		return
+	case "google.protobuf.Value":
+		m.Set(md.Fields().ByName("string_value"), protoreflect.ValueOfString("string"))
+		return
+	case "google.type.Money":
+		set(m, "currency_code", "USD")
+		set(m, "units", int64(12))
+		set(m, "nanos", int32(340000000))
+		return
+	case "google.type.Date":
+		set(m, "year", int32(sampleTime.Year()))
+		set(m, "month", int32(sampleTime.Month()))
+		set(m, "day", int32(sampleTime.Day()))
+		return
+	case "google.type.TimeOfDay":
+		set(m, "hours", int32(sampleTime.Hour()))
+		set(m, "minutes", int32(sampleTime.Minute()))
+		set(m, "seconds", int32(sampleTime.Second()))
+		return
+	case "google.type.LatLng":
+		set(m, "latitude", 51.4779)
+		set(m, "longitude", -0.0015)
+		return
+	}
+	parents[md.FullName()] = true
+	defer delete(parents, md.FullName())
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && od.Fields().Get(0) != fd {
+			continue
+		}
+		if fd.Name() == "validate_only" && fd.Kind() == protoreflect.BoolKind {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			if !canPopulate(fd.MapValue(), parents) {
+				continue
+			}
+			entries := m.Mutable(fd).Map()
+			v := entries.NewValue()
+			if fd.MapValue().Message() == nil {
+				v = scalar(fd.MapValue())
+			} else {
+				populate(v.Message(), parents)
+			}
+			entries.Set(scalar(fd.MapKey()).MapKey(), v)
+		case fd.IsList():
+			if !canPopulate(fd, parents) {
+				continue
+			}
+			list := m.Mutable(fd).List()
+			v := list.NewElement()
+			if fd.Message() == nil {
+				v = scalar(fd)
+			} else {
+				populate(v.Message(), parents)
+			}
+			list.Append(v)
+		case fd.Message() != nil:
+			if canPopulate(fd, parents) {
+				populate(m.Mutable(fd).Message(), parents)
+			}
+		default:
+			m.Set(fd, scalar(fd))
+		}
+	}
+}
+
+// set sets the field of m named name to v.
+func set(m protoreflect.Message, name string, v interface{}) {
+	m.Set(m.Descriptor().Fields().ByName(protoreflect.Name(name)), protoreflect.ValueOf(v))
+}
+
+// canPopulate reports whether values of fd may be populated: they are not
+// messages of a type in parents, or Any messages.
+func canPopulate(fd protoreflect.FieldDescriptor, parents map[protoreflect.FullName]bool) bool {
+	md := fd.Message()
+	return md == nil || !parents[md.FullName()] && md.FullName() != "google.protobuf.Any"
+}
+
+// scalar returns the placeholder value of the non-message field fd.
+func scalar(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString("string")
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte("bytes"))
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			if n := values.Get(i).Number(); n != 0 {
+				return protoreflect.ValueOfEnum(n)
+			}
+		}
+		return protoreflect.ValueOfEnum(0)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(1)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(1)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(1)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(1)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(1)
+	}
+	return protoreflect.Value{}
+}